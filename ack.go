@@ -0,0 +1,146 @@
+package ringslice
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"time"
+)
+
+var ErrUnknownItem = errors.New("item is not claimed or was already acknowledged")
+
+// claimed is an item delivered by Claim and not acknowledged yet
+type claimed[T any] struct {
+	v        T
+	deadline time.Time // zero if the item does not time out
+}
+
+// SetAckTimeout sets how long a worker has to acknowledge an item returned by
+// Claim before it is delivered to another worker. A zero duration, the
+// default, means items are only redelivered after Nack.
+func (q *WorkQueue[T]) SetAckTimeout(d time.Duration) {
+	q.amu.Lock()
+	defer q.amu.Unlock()
+
+	q.timeout = d
+}
+
+// Claim returns an element to process with its sequence number, blocking
+// until one is available. Unlike TakeOne, the element must be acknowledged
+// with Ack once processed; it is delivered again, to any worker, after Nack
+// or once the acknowledgement timeout has elapsed (see SetAckTimeout).
+// Redelivered elements are returned before new ones.
+func (q *WorkQueue[T]) Claim() (int64, T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// the deadlines set below only apply to Claim, not to later reads
+	defer q.r.SetReadDeadline(q.r.readDeadline())
+
+	for {
+		q.amu.Lock()
+		if seq, v, ok := q.redeliver(); ok {
+			q.amu.Unlock()
+			return seq, v, nil
+		}
+		// wake up when the next claimed item times out
+		q.r.SetReadDeadline(q.nextTimeout())
+		q.amu.Unlock()
+
+		v, err := q.r.ReadOne()
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			continue
+		}
+		if err != nil {
+			return 0, v, err
+		}
+
+		// on a framed buffer, the reader is at the end of the frame
+		// being returned
+		seq := q.r.pos.Load() - int64(len(q.r.ahead)) - 1
+		q.amu.Lock()
+		q.track(seq, v)
+		q.amu.Unlock()
+		return seq, v, nil
+	}
+}
+
+// Ack acknowledges that the item with the given sequence number was
+// processed, so it will not be delivered again.
+func (q *WorkQueue[T]) Ack(seq int64) error {
+	q.amu.Lock()
+	defer q.amu.Unlock()
+
+	if _, ok := q.claims[seq]; !ok {
+		return ErrUnknownItem
+	}
+	delete(q.claims, seq)
+	q.retry = slices.DeleteFunc(q.retry, func(s int64) bool { return s == seq })
+	return nil
+}
+
+// Nack reports that the item with the given sequence number could not be
+// processed, so it is delivered again to the next caller of Claim.
+func (q *WorkQueue[T]) Nack(seq int64) error {
+	q.amu.Lock()
+	defer q.amu.Unlock()
+
+	c, ok := q.claims[seq]
+	if !ok || slices.Contains(q.retry, seq) {
+		return ErrUnknownItem
+	}
+	c.deadline = time.Time{}
+	q.retry = append(q.retry, seq)
+
+	// wake a worker waiting in Claim
+	q.r.SetReadDeadline(q.r.w.clk().Now())
+	return nil
+}
+
+// track records a claimed item. The caller must hold amu.
+func (q *WorkQueue[T]) track(seq int64, v T) {
+	if q.claims == nil {
+		q.claims = make(map[int64]*claimed[T])
+	}
+	c := &claimed[T]{v: v}
+	if q.timeout > 0 {
+		c.deadline = q.r.w.clk().Now().Add(q.timeout)
+	}
+	q.claims[seq] = c
+}
+
+// redeliver returns an item to deliver again, either nacked or timed out, and
+// renews its claim. The caller must hold amu.
+func (q *WorkQueue[T]) redeliver() (int64, T, bool) {
+	seq := int64(-1)
+	if len(q.retry) > 0 {
+		seq = q.retry[0]
+		q.retry = q.retry[1:]
+	} else {
+		now := q.r.w.clk().Now()
+		for s, c := range q.claims {
+			if !c.deadline.IsZero() && !c.deadline.After(now) && (seq < 0 || s < seq) {
+				seq = s
+			}
+		}
+	}
+	if seq < 0 {
+		return 0, empty[T](), false
+	}
+
+	c := q.claims[seq]
+	q.track(seq, c.v)
+	return seq, c.v, true
+}
+
+// nextTimeout returns the earliest deadline of claimed items, or zero. The
+// caller must hold amu.
+func (q *WorkQueue[T]) nextTimeout() time.Time {
+	var res time.Time
+	for _, c := range q.claims {
+		if !c.deadline.IsZero() && (res.IsZero() || c.deadline.Before(res)) {
+			res = c.deadline
+		}
+	}
+	return res
+}
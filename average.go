@@ -0,0 +1,97 @@
+package ringslice
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sync/atomic"
+)
+
+// MovingAverage maintains the average of the last elements written to a
+// Writer, updated incrementally on each write.
+type MovingAverage[T Number] struct {
+	w       *Writer[T]
+	fn      *func(start, end int64)
+	window  []T // last values, in circular order
+	next    int // index in window of the next value
+	count   int // number of values in window
+	sum     float64
+	current atomic.Uint64 // float64 bits of the average
+}
+
+// NewMovingAverage returns a MovingAverage of the last n elements written to
+// w, with n no larger than the size of w. The average is initialized from
+// the elements currently retained in w.
+func NewMovingAverage[T Number](w *Writer[T], n int) (*MovingAverage[T], error) {
+	if n <= 0 || int64(n) > w.size {
+		return nil, errors.New("Window must be positive and no larger than the buffer")
+	}
+
+	m := &MovingAverage[T]{w: w, window: make([]T, n)}
+	fn := func(start, end int64) {
+		// only the last values written can be part of the window
+		start = max(start, end-int64(n))
+		w.data.segments(start, end-start, func(seg []T) error {
+			m.add(seg)
+			return nil
+		})
+		m.update()
+	}
+	m.fn = &fn
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	head := w.head.Load()
+	fn(w.oldest(head), head)
+	w.observers = append(w.observers, m.fn)
+	return m, nil
+}
+
+// add pushes values in the window.
+func (m *MovingAverage[T]) add(values []T) {
+	for _, v := range values {
+		if m.count == len(m.window) {
+			m.sum -= float64(m.window[m.next])
+		} else {
+			m.count++
+		}
+		m.window[m.next] = v
+		m.sum += float64(v)
+		m.next = (m.next + 1) % len(m.window)
+
+		if m.next == 0 {
+			// recompute the sum once per cycle so rounding errors do not
+			// accumulate
+			m.sum = 0
+			for _, v := range m.window {
+				m.sum += float64(v)
+			}
+		}
+	}
+}
+
+// update stores the current average.
+func (m *MovingAverage[T]) update() {
+	avg := 0.0
+	if m.count > 0 {
+		avg = m.sum / float64(m.count)
+	}
+	m.current.Store(math.Float64bits(avg))
+}
+
+// Current returns the average of the last elements written, or zero if no
+// element was written yet. It does not lock the buffer.
+func (m *MovingAverage[T]) Current() float64 {
+	return math.Float64frombits(m.current.Load())
+}
+
+// Close stops updating the average.
+func (m *MovingAverage[T]) Close() error {
+	w := m.w
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.observers = slices.DeleteFunc(w.observers, func(fn *func(start, end int64)) bool { return fn == m.fn })
+	return nil
+}
@@ -0,0 +1,41 @@
+package ringslice
+
+import "testing"
+
+func TestMovingAverage(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if _, err := NewMovingAverage(w, 9); err == nil {
+		t.Errorf("failed moving average window test, expected error")
+	}
+
+	w.Append(10, 2, 4)
+	m, err := NewMovingAverage(w, 2)
+	if err != nil {
+		t.Errorf("failed to initialize moving average")
+		return
+	}
+	if v := m.Current(); v != 3 {
+		t.Errorf("failed moving average init test, expected 3, got %v", v)
+	}
+
+	w.Append(6)
+	if v := m.Current(); v != 5 {
+		t.Errorf("failed moving average test, expected 5, got %v", v)
+	}
+
+	w.Append(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 20)
+	if v := m.Current(); v != 15 {
+		t.Errorf("failed moving average large write test, expected 15, got %v", v)
+	}
+
+	m.Close()
+	w.Append(100)
+	if v := m.Current(); v != 15 {
+		t.Errorf("failed moving average close test, expected 15, got %v", v)
+	}
+}
@@ -0,0 +1,82 @@
+package ringslice
+
+import (
+	"testing"
+	"time"
+)
+
+func BenchmarkWrite(b *testing.B) {
+	w, _ := New[int](4096)
+	values := make([]int, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Write(values)
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	w, _ := New[int](4096)
+	r := w.Reader()
+	r.SetAutoSkip(true)
+	values := make([]int, 64)
+	rbuf := make([]int, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Write(values)
+		r.Read(rbuf)
+	}
+}
+
+func BenchmarkReadShared(b *testing.B) {
+	w, _ := New[int](4096)
+	r := w.Reader()
+	r.SetAutoSkip(true)
+	w.Reader() // second reader disables the lock-free path
+	values := make([]int, 64)
+	rbuf := make([]int, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Write(values)
+		r.Read(rbuf)
+	}
+}
+
+func BenchmarkReadOne(b *testing.B) {
+	w, _ := New[int](4096)
+	r := w.Reader()
+	r.SetAutoSkip(true)
+	values := make([]int, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if i%64 == 0 {
+			w.Write(values)
+		}
+		r.ReadOne()
+	}
+}
+
+func BenchmarkSeekToTime(b *testing.B) {
+	const size = 1 << 22
+	clock := NewManualClock(time.Now())
+	w, _ := NewChunked[int](size, 1<<16)
+	w.SetClock(clock)
+	w.SetTimestamps(true)
+
+	values := make([]int, 1024)
+	for i := 0; i < size/len(values); i++ {
+		w.Write(values)
+		clock.Advance(time.Millisecond)
+	}
+	start := clock.Now().Add(-size / 1024 * time.Millisecond)
+	r := w.Reader()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.SeekToTime(start.Add(time.Duration(i%(size/1024)) * time.Millisecond))
+	}
+}
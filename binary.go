@@ -0,0 +1,104 @@
+package ringslice
+
+import (
+	"encoding/binary"
+	"reflect"
+	"unsafe"
+)
+
+// binaryMagic identifies data written by MarshalBinary
+const binaryMagic = "RSB\x01"
+
+// nativeBig is true if the host stores integers as big endian
+var nativeBig = binary.NativeEndian.Uint16([]byte{0, 1}) == 1
+
+// MarshalBinary implements encoding.BinaryMarshaler for buffers whose
+// element type has a fixed size and contains no pointers (see ErrNotPlain).
+// Elements are copied as raw memory rather than encoded one by one, which
+// makes it suitable for very large numeric buffers. The encoding depends on
+// the host's byte order, which UnmarshalBinary checks.
+func (w *Writer[T]) MarshalBinary() ([]byte, error) {
+	if !isPlain(reflect.TypeFor[T]()) {
+		return nil, ErrNotPlain
+	}
+	elemSize := int64(unsafe.Sizeof(empty[T]()))
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head := w.head.Load()
+	oldest := w.oldest(head)
+	count := head - oldest
+
+	buf := make([]byte, 0, len(binaryMagic)+1+4*binary.MaxVarintLen64+int(count*elemSize))
+	buf = append(buf, binaryMagic...)
+	if nativeBig {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	buf = binary.AppendUvarint(buf, uint64(elemSize))
+	buf = binary.AppendUvarint(buf, uint64(w.size))
+	buf = binary.AppendUvarint(buf, uint64(head))
+	buf = binary.AppendUvarint(buf, uint64(count))
+
+	w.data.segments(oldest, count, func(seg []T) error {
+		buf = append(buf, rawBytes(seg)...)
+		return nil
+	})
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, restoring a buffer
+// encoded by MarshalBinary on a host with the same byte order. It is meant to
+// be called on a zero Writer and must not be called on a Writer in use.
+func (w *Writer[T]) UnmarshalBinary(data []byte) error {
+	if !isPlain(reflect.TypeFor[T]()) {
+		return ErrNotPlain
+	}
+	elemSize := uint64(unsafe.Sizeof(empty[T]()))
+
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrInvalidState
+	}
+	if (data[len(binaryMagic)] == 1) != nativeBig {
+		return ErrInvalidState
+	}
+	data = data[len(binaryMagic)+1:]
+
+	var hdr [4]uint64
+	for i := range hdr {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrInvalidState
+		}
+		hdr[i] = v
+		data = data[n:]
+	}
+	if hdr[0] != elemSize {
+		return ErrInvalidState
+	}
+	size, head, count := int64(hdr[1]), int64(hdr[2]), int64(hdr[3])
+	if err := checkState(size, head, count); err != nil {
+		return err
+	}
+	if uint64(len(data)) != uint64(count)*elemSize {
+		return ErrInvalidState
+	}
+
+	w.init(size, 0)
+	w.data.segments(head-count, count, func(seg []T) error {
+		data = data[copy(rawBytes(seg), data):]
+		return nil
+	})
+	w.restore(head, head-count)
+	return nil
+}
+
+// rawBytes returns the memory of s as a byte slice. T must be plain.
+func rawBytes[T any](s []T) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), len(s)*int(unsafe.Sizeof(s[0])))
+}
@@ -0,0 +1,51 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	type sample struct {
+		T int64
+		V float64
+	}
+
+	w, err := New[sample](3)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(sample{1, 1.5}, sample{2, 2.5}, sample{3, 3.5}, sample{4, 4.5})
+
+	data, err := w.MarshalBinary()
+	if err != nil {
+		t.Errorf("failed binary marshal test, got err=%v", err)
+		return
+	}
+
+	var res Writer[sample]
+	if err := res.UnmarshalBinary(data); err != nil {
+		t.Errorf("failed binary unmarshal test, got err=%v", err)
+		return
+	}
+	if res.TotalWritten() != 4 || !slices.Equal(res.Snapshot(), w.Snapshot()) {
+		t.Errorf("failed binary unmarshal test, expected %v, got %v", w.Snapshot(), res.Snapshot())
+	}
+
+	res.Append(sample{5, 5.5})
+	if s := res.Snapshot(); !slices.Equal(s, []sample{{3, 3.5}, {4, 4.5}, {5, 5.5}}) {
+		t.Errorf("failed binary unmarshal append test, got %v", s)
+	}
+
+	// element size mismatch
+	var res2 Writer[int32]
+	if err := res2.UnmarshalBinary(data); err != ErrInvalidState {
+		t.Errorf("failed binary mismatch test, expected ErrInvalidState, got %v", err)
+	}
+
+	s, _ := New[string](2)
+	if _, err := s.MarshalBinary(); err != ErrNotPlain {
+		t.Errorf("failed binary plain test, expected ErrNotPlain, got %v", err)
+	}
+}
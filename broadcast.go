@@ -0,0 +1,140 @@
+package ringslice
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// broadcastBatch is the maximum number of elements read at once per
+// connection
+const broadcastBatch = 64
+
+// BroadcastConn is a message oriented connection, such as a WebSocket
+// connection, that a Broadcaster sends elements to.
+type BroadcastConn interface {
+	WriteMessage(data []byte) error
+	Close() error
+}
+
+// Broadcaster sends the elements written to a buffer to a dynamic set of
+// connections, each one being served by its own goroutine and reader so a
+// slow connection does not delay the others.
+type Broadcaster[T any] struct {
+	w       *Writer[T]
+	encode  func(T) ([]byte, error)
+	maxMiss int64
+	dropped atomic.Int64
+
+	mu    sync.Mutex
+	conns map[BroadcastConn]*Reader[T]
+	wg    sync.WaitGroup
+}
+
+// NewBroadcaster returns a Broadcaster sending the elements of w, encoded
+// with encode. Elements which fail to encode are skipped. Connections are
+// served with auto skip enabled, so they skip elements overwritten before
+// being sent: a connection which missed more than maxMissed elements this
+// way is considered too slow and dropped. A negative maxMissed disables
+// dropping.
+func NewBroadcaster[T any](w *Writer[T], encode func(T) ([]byte, error), maxMissed int64) *Broadcaster[T] {
+	return &Broadcaster[T]{
+		w:       w,
+		encode:  encode,
+		maxMiss: maxMissed,
+		conns:   make(map[BroadcastConn]*Reader[T]),
+	}
+}
+
+// Add starts sending elements written from now on to c, until c fails, is
+// dropped or the writer is closed. c is closed when it is removed.
+func (b *Broadcaster[T]) Add(c BroadcastConn) error {
+	r, err := b.w.NewBlockingCurrentReader()
+	if err != nil {
+		c.Close()
+		return err
+	}
+	r.SetAutoSkip(true)
+
+	b.mu.Lock()
+	b.conns[c] = r
+	b.wg.Add(1)
+	b.mu.Unlock()
+
+	go b.serve(c, r)
+	return nil
+}
+
+func (b *Broadcaster[T]) serve(c BroadcastConn, r *Reader[T]) {
+	defer b.wg.Done()
+	defer b.remove(c)
+
+	buf := make([]T, broadcastBatch)
+	var missed int64
+	for {
+		n, err := r.Read(buf)
+		// only overwritten elements count, not those skipped for being
+		// expired or truncated
+		for _, g := range r.Gaps() {
+			missed += g[1] - g[0]
+		}
+		if b.maxMiss >= 0 && missed > b.maxMiss {
+			b.dropped.Add(1)
+			return
+		}
+		for _, v := range buf[:n] {
+			data, err := b.encode(v)
+			if err != nil {
+				continue
+			}
+			if c.WriteMessage(data) != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// remove closes c and its reader.
+func (b *Broadcaster[T]) remove(c BroadcastConn) {
+	b.mu.Lock()
+	r, ok := b.conns[c]
+	delete(b.conns, c)
+	b.mu.Unlock()
+
+	if ok {
+		r.Close()
+		c.Close()
+	}
+}
+
+// Len returns the number of connections being served.
+func (b *Broadcaster[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.conns)
+}
+
+// Dropped returns the number of connections dropped for being too slow.
+func (b *Broadcaster[T]) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close removes and closes all connections.
+func (b *Broadcaster[T]) Close() error {
+	b.mu.Lock()
+	readers := make([]*Reader[T], 0, len(b.conns))
+	for _, r := range b.conns {
+		readers = append(readers, r)
+	}
+	b.mu.Unlock()
+
+	// closing readers stops the goroutines, which close the connections
+	for _, r := range readers {
+		r.Close()
+	}
+	b.wg.Wait()
+	return nil
+}
@@ -0,0 +1,115 @@
+package ringslice
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testConn struct {
+	mu     sync.Mutex
+	msgs   []string
+	closed bool
+	delay  time.Duration
+}
+
+func (c *testConn) WriteMessage(data []byte) error {
+	time.Sleep(c.delay)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.msgs = append(c.msgs, string(data))
+	return nil
+}
+
+func (c *testConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *testConn) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.msgs)
+}
+
+func (c *testConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func TestBroadcaster(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	b := NewBroadcaster(w, func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil }, 0)
+	fast := &testConn{}
+	slow := &testConn{delay: 20 * time.Millisecond}
+	b.Add(fast)
+	b.Add(slow)
+	if b.Len() != 2 {
+		t.Errorf("failed broadcast add test, expected 2 connections, got %d", b.Len())
+	}
+
+	for round := 1; round <= 5; round++ {
+		for i := 0; i < 10; i++ {
+			w.Append(i)
+		}
+		for i := 0; fast.count() < round*10 && i < 100; i++ {
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	if n := fast.count(); n != 50 {
+		t.Errorf("failed broadcast test, expected 50 messages, got %d", n)
+	}
+
+	for i := 0; b.Len() > 1 && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if b.Len() != 1 || b.Dropped() != 1 || !slow.isClosed() {
+		t.Errorf("failed broadcast drop test, expected 1 connection and 1 dropped, got %d and %d", b.Len(), b.Dropped())
+	}
+
+	b.Close()
+	if b.Len() != 0 || !fast.isClosed() {
+		t.Errorf("failed broadcast close test")
+	}
+	if s := w.Stats(); s.Readers != 0 {
+		t.Errorf("failed broadcast close test, expected no readers, got %d", s.Readers)
+	}
+}
+
+func TestBroadcasterExpired(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	clock := NewManualClock(time.Unix(0, 0))
+	w.SetClock(clock)
+
+	b := NewBroadcaster(w, func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil }, 0)
+	defer b.Close()
+	c := &testConn{delay: 20 * time.Millisecond}
+	b.Add(c)
+
+	// expired elements written while the connection is busy are skipped
+	// without counting as missed
+	w.Append(0)
+	time.Sleep(5 * time.Millisecond)
+	w.WriteTTL([]int{1, 2, 3}, time.Second)
+	clock.Advance(2 * time.Second)
+	w.Append(4)
+	for i := 0; c.count() < 2 && i < 100; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if b.Len() != 1 || b.Dropped() != 0 {
+		t.Errorf("failed broadcast expiry test, expected no drop, got %d dropped", b.Dropped())
+	}
+}
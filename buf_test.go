@@ -1,7 +1,9 @@
 package ringslice
 
 import (
+	"errors"
 	"io"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -152,3 +154,376 @@ func TestShort(t *testing.T) {
 		t.Errorf("failed simple test, expected to read back world, got n=%d err=%v", n, err)
 	}
 }
+
+func TestReadOneAhead(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	w.Append(1, 2, 3)
+
+	v, err := r.ReadOne()
+	if v != 1 || err != nil {
+		t.Errorf("failed ReadOne test, expected 1, got v=%d err=%v", v, err)
+	}
+
+	// remaining values were fetched at once, overwriting them doesn't matter
+	w.Append(4, 5, 6, 7, 8, 9, 10, 11, 12)
+
+	v, err = r.ReadOne()
+	if v != 2 || err != nil {
+		t.Errorf("failed ReadOne test, expected 2, got v=%d err=%v", v, err)
+	}
+
+	rbuf := make([]int, 8)
+	n, err := r.Read(rbuf)
+	if n != 1 || err != nil || rbuf[0] != 3 {
+		t.Errorf("failed Read after ReadOne test, expected 3, got n=%d err=%v", n, err)
+	}
+
+	// the reader is now stale
+	_, err = r.ReadOne()
+	if err != ErrStaleReader {
+		t.Errorf("failed ReadOne stale test, expected stale reader, got err=%v", err)
+	}
+}
+
+func TestTotalWritten(t *testing.T) {
+	w, err := New[byte](10)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Write([]byte("hello"))
+	if w.TotalWritten() != 5 || w.Len() != 5 {
+		t.Errorf("failed TotalWritten test, expected 5/5, got %d/%d", w.TotalWritten(), w.Len())
+	}
+
+	w.Write([]byte("helloworld2"))
+	if w.TotalWritten() != 16 || w.Len() != 10 {
+		t.Errorf("failed TotalWritten test, expected 16/10, got %d/%d", w.TotalWritten(), w.Len())
+	}
+
+	r := w.Reader()
+	rbuf := make([]byte, 32)
+	n, err := r.Read(rbuf)
+	if n != 10 || err != nil || string(rbuf[:n]) != "elloworld2" {
+		t.Errorf("failed oversized write test, expected elloworld2, got n=%d err=%v", n, err)
+	}
+}
+
+func TestExclusiveReader(t *testing.T) {
+	w, err := New[int](64)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.BlockingReader()
+	r.SetAutoSkip(true)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer r.Close()
+
+		rbuf := make([]int, 16)
+		last := -1
+		for {
+			n, err := r.Read(rbuf)
+			if err != nil {
+				if err != io.EOF {
+					t.Errorf("failed exclusive reader test, got err=%v", err)
+				}
+				return
+			}
+			for _, v := range rbuf[:n] {
+				if v <= last {
+					t.Errorf("failed exclusive reader test, got %d after %d", v, last)
+					return
+				}
+				last = v
+			}
+		}
+	}()
+
+	for i := 0; i < 10000; i += 4 {
+		w.Append(i, i+1, i+2, i+3)
+	}
+	w.Close()
+	<-done
+}
+
+func TestChunked(t *testing.T) {
+	rbuf := make([]byte, 32)
+
+	w, err := NewChunked[byte](10, 4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if w.data.pages[0] != nil {
+		t.Errorf("failed chunked test, expected pages to be allocated on demand")
+	}
+
+	r := w.Reader()
+	w.Write([]byte("hello"))
+
+	n, err := r.Read(rbuf)
+	if n != 5 || err != nil || string(rbuf[:n]) != "hello" {
+		t.Errorf("failed chunked test, expected to read back hello, got n=%d err=%v", n, err)
+	}
+
+	w.Write([]byte("world!!!"))
+
+	n, err = r.Read(rbuf)
+	if n != 8 || err != nil || string(rbuf[:n]) != "world!!!" {
+		t.Errorf("failed chunked test, expected to read back world!!!, got n=%d err=%v", n, err)
+	}
+
+	// keep only "!!!", which wrapped around to the first page
+	w.Truncate(3)
+	if w.data.pages[0] == nil || w.data.pages[1] != nil || w.data.pages[2] != nil {
+		t.Errorf("failed chunked truncate test, expected last pages to be released")
+	}
+	if w.Len() != 3 {
+		t.Errorf("failed chunked truncate test, expected Len()=3, got %d", w.Len())
+	}
+
+	r2 := w.Reader()
+	n, err = r2.Read(rbuf)
+	if n != 3 || err != nil || string(rbuf[:n]) != "!!!" {
+		t.Errorf("failed chunked truncate test, expected to read back !!!, got n=%d err=%v", n, err)
+	}
+
+	w.Write([]byte("abc"))
+	n, err = r.Read(rbuf)
+	if n != 3 || err != nil || string(rbuf[:n]) != "abc" {
+		t.Errorf("failed chunked test, expected to read back abc, got n=%d err=%v", n, err)
+	}
+}
+
+func TestZeroAlloc(t *testing.T) {
+	w, err := New[int](128)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	r.SetAutoSkip(true)
+	r2 := w.Reader()
+	r2.SetAutoSkip(true)
+	r.ReadOne() // allocates the read-ahead buffer
+
+	rbuf := make([]int, 8)
+	values := []int{1, 2, 3, 4}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		w.Write(values)
+		w.Append(5, 6)
+		r.ReadOne()
+		r.Read(rbuf)
+		r2.Read(rbuf)
+		r.Read(rbuf) // io.EOF
+	})
+	if allocs != 0 {
+		t.Errorf("failed zero allocation test, got %v allocations per run", allocs)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	if _, err := New[int](0); err != ErrInvalidSize {
+		t.Errorf("failed invalid size test, expected ErrInvalidSize, got %v", err)
+	}
+
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r, err := w.NewReader()
+	if r == nil || err != nil {
+		t.Errorf("failed new reader test, got err=%v", err)
+		return
+	}
+	r.Close()
+	w.Close()
+
+	rr := w.ReplicaReader(0)
+	defer rr.Close()
+	feed := func() (*Reader[int], error) { return rr.NewFeed(rr.Snapshot()) }
+	sub := func() (*Reader[int], error) { return w.NewSubscription(func(int) bool { return true }) }
+	for _, fn := range []func() (*Reader[int], error){w.NewReader, w.NewBlockingReader, w.NewBlockingCurrentReader, feed, sub} {
+		if r, err := fn(); r != nil || err != ErrWriterClosed || !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("failed closed writer test, expected ErrWriterClosed, got %v", err)
+		}
+	}
+	if s, err := w.NewSamplingReader(time.Second); s != nil || err != ErrWriterClosed {
+		t.Errorf("failed closed writer sampling reader test, expected ErrWriterClosed, got %v", err)
+	}
+	if q, err := w.NewWorkQueue(); q != nil || err != ErrWriterClosed {
+		t.Errorf("failed closed writer work queue test, expected ErrWriterClosed, got %v", err)
+	}
+	if g, err := w.NewConsumerGroup("g"); g != nil || err != ErrWriterClosed {
+		t.Errorf("failed closed writer consumer group test, expected ErrWriterClosed, got %v", err)
+	}
+	if r, err := w.ResumeReader("r", NewFilePositionStore(filepath.Join(t.TempDir(), "pos"))); r != nil || err != ErrWriterClosed {
+		t.Errorf("failed closed writer resume reader test, expected ErrWriterClosed, got %v", err)
+	}
+}
+
+func TestReaderClosed(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	if r.Closed() {
+		t.Errorf("failed closed test, reader should be open")
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("failed close test, expected nil, got %v", err)
+	}
+	if !r.Closed() {
+		t.Errorf("failed closed test, reader should be closed")
+	}
+	if err := r.Close(); err != ErrReaderClosed || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("failed double close test, expected ErrReaderClosed, got %v", err)
+	}
+	if s := w.Stats(); s.Readers != 0 {
+		t.Errorf("failed double close test, expected no readers, got %d", s.Readers)
+	}
+	if w.Closed() {
+		t.Errorf("failed closed test, writer should be open")
+	}
+	w.Close()
+	if !w.Closed() {
+		t.Errorf("failed closed test, writer should be closed")
+	}
+}
+
+func TestStrictEOF(t *testing.T) {
+	w, err := New[int](4, WithStrictEOF(true))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	r2 := w.Reader()
+	defer r2.Close()
+	r2.SetStrictEOF(false)
+	buf := make([]int, 4)
+
+	if _, err := r.Read(buf); err != ErrNoData {
+		t.Errorf("failed strict eof test, expected ErrNoData, got %v", err)
+	}
+	if _, err := r2.Read(buf); err != io.EOF {
+		t.Errorf("failed non strict eof test, expected io.EOF, got %v", err)
+	}
+	w.Append(1)
+	w.closeWithError(nil)
+	if n, err := r.Read(buf); n != 1 || err != nil {
+		t.Errorf("failed strict eof test, expected 1 element, got %d err=%v", n, err)
+	}
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Errorf("failed strict eof test, expected io.EOF, got %v", err)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	w.Append(1, 2, 3)
+	if v, err := r.ReadOne(); v != 1 || err != nil {
+		t.Errorf("failed drain test, expected 1, got %d err=%v", v, err)
+	}
+	if n, err := r.Drain(); n != 2 || err != nil {
+		t.Errorf("failed drain test, expected 2 elements, got %d err=%v", n, err)
+	}
+	if _, err := r.ReadOne(); err != io.EOF {
+		t.Errorf("failed drain test, expected io.EOF, got %v", err)
+	}
+
+	// drain a stale reader
+	w.Append(4, 5, 6, 7, 8, 9)
+	if n, err := r.Drain(); n != 6 || err != nil {
+		t.Errorf("failed stale drain test, expected 6 elements, got %d err=%v", n, err)
+	}
+	w.Append(10)
+	if v, err := r.ReadOne(); v != 10 || err != nil {
+		t.Errorf("failed drain test, expected 10, got %d err=%v", v, err)
+	}
+
+	r.Close()
+	if _, err := r.Drain(); err != io.ErrClosedPipe {
+		t.Errorf("failed closed drain test, expected io.ErrClosedPipe, got %v", err)
+	}
+}
+
+func TestPin(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	lagging := w.Reader()
+	defer lagging.Close()
+
+	if err := r.Pin(); err != nil {
+		t.Errorf("failed pin test, expected nil, got %v", err)
+	}
+	w.Append(1, 2, 3, 4)
+
+	done := make(chan struct{})
+	go func() {
+		w.Append(5, 6)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Errorf("failed pin test, write should wait for the pinned reader")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	buf := make([]int, 4)
+	if n, err := r.Read(buf); n != 4 || err != nil {
+		t.Errorf("failed pin test, expected 4 elements, got %d err=%v", n, err)
+	}
+	<-done
+	if n, err := r.Read(buf); n != 2 || err != nil || buf[0] != 5 {
+		t.Errorf("failed pin test, expected [5 6], got %v err=%v", buf[:n], err)
+	}
+
+	// the other reader was not pinned and missed data
+	if _, err := lagging.Read(buf); err != ErrStaleReader {
+		t.Errorf("failed pin test, expected ErrStaleReader, got %v", err)
+	}
+	if err := lagging.Pin(); err != ErrStaleReader {
+		t.Errorf("failed stale pin test, expected ErrStaleReader, got %v", err)
+	}
+
+	r.Unpin()
+	w.Append(7, 8, 9, 10, 11)
+	if _, err := r.Read(buf); err != ErrStaleReader {
+		t.Errorf("failed unpin test, expected ErrStaleReader, got %v", err)
+	}
+}
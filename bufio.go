@@ -0,0 +1,156 @@
+package ringslice
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// defaultBufSize is the buffer size of a BufReader if none is given
+const defaultBufSize = 4096
+
+// BufReader wraps a Reader[byte] with a buffer, and provides the methods of
+// bufio.Reader so protocol code written against it can read from the ring.
+// It is a ByteReader using the reader's read-ahead window as its buffer, and
+// adds the methods that give access to the buffered data.
+//
+// Unlike bufio.Reader, errors are not sticky: on a non-blocking reader, io.EOF
+// means no data is available yet until the writer is closed. In that case,
+// Peek, ReadRune, ReadSlice and ReadBytes keep the partial data buffered and
+// return io.EOF, and a later call returns the complete data. Other errors,
+// such as ErrStaleReader, are returned as is, with the data still buffered.
+type BufReader struct {
+	*ByteReader
+}
+
+// NewBufReader returns a BufReader reading from r with a buffer of the given
+// size, or a default size if size is too small. It sets the read-ahead of r
+// to the buffer size.
+func NewBufReader(r *Reader[byte], size int) *BufReader {
+	if size < 16 {
+		size = defaultBufSize
+	}
+	r.SetReadAhead(size)
+	return &BufReader{NewByteReader(r)}
+}
+
+// Size returns the size of the buffer in bytes.
+func (b *BufReader) Size() int {
+	return b.readAhead
+}
+
+// Buffered returns the number of bytes that can be read from the buffer
+// without reading from the ring.
+func (b *BufReader) Buffered() int {
+	return b.npend + len(b.ahead)
+}
+
+// gather moves bytes kept by ByteReader in front of the read-ahead window, so
+// the buffered data is contiguous.
+func (b *BufReader) gather() {
+	b.setLast(nil, false)
+	if b.npend == 0 {
+		return
+	}
+	b.ahead = append(b.pend[:b.npend:b.npend], b.ahead...)
+	b.npend = 0
+}
+
+// fill reads more data after the buffered data, which must be smaller than
+// the buffer.
+func (b *BufReader) fill() error {
+	r := b.Reader
+	if len(r.aheadBuf) != b.Size() {
+		r.aheadBuf = make([]byte, b.Size())
+	}
+	n := copy(r.aheadBuf, r.ahead)
+	m, err := r.read(r.aheadBuf[n:])
+	r.ahead = r.aheadBuf[:n+m]
+	if m > 0 {
+		return nil
+	}
+	if err == nil {
+		return io.ErrNoProgress
+	}
+	return err
+}
+
+// transient returns true if err only means that no data is available yet.
+func (b *BufReader) transient(err error) bool {
+	return err == ErrNoData || (err == io.EOF && !b.w.isClosed())
+}
+
+// Peek returns the next n bytes without advancing the reader. The bytes stop
+// being valid at the next read call. If Peek returns fewer than n bytes, it
+// also returns an error explaining why, which is bufio.ErrBufferFull if n is
+// larger than the buffer size.
+func (b *BufReader) Peek(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, bufio.ErrNegativeCount
+	}
+	b.gather()
+	if n > b.Size() {
+		return b.ahead, bufio.ErrBufferFull
+	}
+
+	for len(b.ahead) < n {
+		if err := b.fill(); err != nil {
+			return b.ahead, err
+		}
+	}
+	return b.ahead[:n], nil
+}
+
+// Discard skips the next n bytes, returning the number of bytes discarded.
+func (b *BufReader) Discard(n int) (int, error) {
+	if n < 0 {
+		return 0, bufio.ErrNegativeCount
+	}
+	b.gather()
+
+	remain := n
+	for {
+		skip := min(len(b.ahead), remain)
+		b.ahead = b.ahead[skip:]
+		remain -= skip
+		if remain == 0 {
+			return n, nil
+		}
+		if err := b.fill(); err != nil {
+			return n - remain, err
+		}
+	}
+}
+
+// ReadSlice reads until the first occurrence of delim and returns a slice
+// pointing at the bytes in the buffer, which stop being valid at the next
+// read call. If the buffer fills without a delimiter, ReadSlice returns the
+// whole buffer and bufio.ErrBufferFull.
+func (b *BufReader) ReadSlice(delim byte) ([]byte, error) {
+	b.gather()
+
+	start := 0 // bytes already searched
+	for {
+		if i := bytes.IndexByte(b.ahead[start:], delim); i >= 0 {
+			line := b.ahead[:start+i+1]
+			b.ahead = b.ahead[start+i+1:]
+			return line, nil
+		}
+		start = len(b.ahead)
+
+		if start >= b.Size() {
+			line := b.ahead
+			b.ahead = nil
+			return line, bufio.ErrBufferFull
+		}
+		if err := b.fill(); err != nil {
+			if b.transient(err) {
+				// keep the partial record
+				return nil, err
+			}
+			line := b.ahead
+			b.ahead = nil
+			return line, err
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package ringslice
+
+import (
+	"bufio"
+	"io"
+	"testing"
+)
+
+var (
+	_ io.Reader      = (*BufReader)(nil)
+	_ io.ByteScanner = (*BufReader)(nil)
+	_ io.RuneScanner = (*BufReader)(nil)
+)
+
+func TestBufReader(t *testing.T) {
+	b, err := NewByteRing(64)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := b.Reader()
+	defer r.Close()
+	br := NewBufReader(r, 16)
+
+	b.WriteString("GET / HTTP/1.1\r\nHost: x")
+	if p, err := br.Peek(3); err != nil || string(p) != "GET" {
+		t.Errorf("failed Peek test, expected GET, got %q err=%v", p, err)
+	}
+	line, err := br.ReadSlice('\n')
+	if err != nil || string(line) != "GET / HTTP/1.1\r\n" {
+		t.Errorf("failed ReadSlice test, expected request line, got %q err=%v", line, err)
+	}
+
+	// the partial line is kept until complete
+	if line, err := br.ReadString('\n'); err != io.EOF || line != "" {
+		t.Errorf("failed partial ReadString test, expected io.EOF, got %q err=%v", line, err)
+	}
+	b.WriteString("\r\n")
+	if line, err := br.ReadString('\n'); err != nil || line != "Host: x\r\n" {
+		t.Errorf("failed ReadString test, expected header, got %q err=%v", line, err)
+	}
+
+	b.WriteString("abcdef")
+	if n, err := br.Discard(2); n != 2 || err != nil {
+		t.Errorf("failed Discard test, expected 2, got %d err=%v", n, err)
+	}
+	if c, err := br.ReadByte(); c != 'c' || err != nil {
+		t.Errorf("failed ReadByte test, expected c, got %q err=%v", c, err)
+	}
+	if err := br.UnreadByte(); err != nil {
+		t.Errorf("failed UnreadByte test, expected nil, got %v", err)
+	}
+	if p, err := br.Peek(4); err != nil || string(p) != "cdef" {
+		t.Errorf("failed Peek test, expected cdef, got %q err=%v", p, err)
+	}
+	if p, err := br.Peek(5); err != io.EOF || string(p) != "cdef" {
+		t.Errorf("failed short Peek test, expected io.EOF, got %q err=%v", p, err)
+	}
+	if _, err := br.Peek(17); err != bufio.ErrBufferFull {
+		t.Errorf("failed large Peek test, expected bufio.ErrBufferFull, got %v", err)
+	}
+	if n, err := br.Discard(10); n != 4 || err != io.EOF {
+		t.Errorf("failed Discard test, expected 4 and io.EOF, got %d err=%v", n, err)
+	}
+}
+
+func TestBufReaderRunes(t *testing.T) {
+	b, err := NewByteRing(64)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := b.Reader()
+	defer r.Close()
+	br := NewBufReader(r, 16)
+
+	b.WriteString("é")
+	b.Write([]byte("€")[:2])
+	if c, size, err := br.ReadRune(); c != 'é' || size != 2 || err != nil {
+		t.Errorf("failed ReadRune test, expected é, got %q size=%d err=%v", c, size, err)
+	}
+	if _, _, err := br.ReadRune(); err != io.EOF {
+		t.Errorf("failed partial ReadRune test, expected io.EOF, got %v", err)
+	}
+	b.Write([]byte("€")[2:])
+	if c, size, err := br.ReadRune(); c != '€' || size != 3 || err != nil {
+		t.Errorf("failed ReadRune test, expected €, got %q size=%d err=%v", c, size, err)
+	}
+	if err := br.UnreadRune(); err != nil {
+		t.Errorf("failed UnreadRune test, expected nil, got %v", err)
+	}
+	if c, _, err := br.ReadRune(); c != '€' || err != nil {
+		t.Errorf("failed ReadRune test, expected €, got %q err=%v", c, err)
+	}
+}
+
+func TestBufReaderStale(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := b.Reader()
+	defer r.Close()
+	br := NewBufReader(r, 16)
+
+	b.WriteString("line1\n")
+	if line, err := br.ReadString('\n'); err != nil || line != "line1\n" {
+		t.Errorf("failed ReadString test, expected line1, got %q err=%v", line, err)
+	}
+	b.WriteString("0123456789abcdefghij")
+	if _, err := br.ReadSlice('\n'); err != ErrStaleReader {
+		t.Errorf("failed stale test, expected ErrStaleReader, got %v", err)
+	}
+
+	// data is returned once the writer is closed
+	r.SetAutoSkip(true)
+	b.closeWithError(nil)
+	line, err := br.ReadString('\n')
+	if err != io.EOF || line != "456789abcdefghij" {
+		t.Errorf("failed close test, expected remaining data, got %q err=%v", line, err)
+	}
+}
@@ -0,0 +1,285 @@
+package ringslice
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// ByteRing is a Writer specialized for bytes, which implements io.Writer,
+// io.StringWriter and io.ByteWriter so it can be used directly as output for
+// fmt.Fprintf, log.Logger or encoders.
+type ByteRing struct {
+	*Writer[byte]
+}
+
+// NewByteRing returns a new ByteRing of the given size.
+func NewByteRing(size int64) (*ByteRing, error) {
+	w, err := New[byte](size)
+	if err != nil {
+		return nil, err
+	}
+	return &ByteRing{w}, nil
+}
+
+// WriteString appends the contents of s to the buffer.
+func (b *ByteRing) WriteString(s string) (int, error) {
+	return b.Write([]byte(s))
+}
+
+// WriteByte appends a single byte to the buffer.
+func (b *ByteRing) WriteByte(c byte) error {
+	_, err := b.Write([]byte{c})
+	return err
+}
+
+// SnapshotReaderAt captures the current contents of the buffer and returns
+// them as a bytes.Reader, which implements io.ReaderAt and io.ReadSeeker for
+// parsers requiring random access. Later writes do not affect the returned
+// reader.
+func (b *ByteRing) SnapshotReaderAt() *bytes.Reader {
+	return bytes.NewReader(b.Snapshot())
+}
+
+// ByteReader wraps a Reader[byte] to implement io.ByteScanner and
+// io.RuneScanner, allowing the ring to feed binary decoders and text scanners
+// directly.
+type ByteReader struct {
+	*Reader[byte]
+
+	// bytes of an incomplete rune, kept until the rest becomes available,
+	// or unread
+	pend  [utf8.UTFMax]byte
+	npend int
+
+	// bytes returned by the last read, for UnreadByte and UnreadRune
+	last     [utf8.UTFMax]byte
+	nlast    int
+	lastRune bool
+
+	// partial record read by ReadBytes
+	line []byte
+}
+
+// NewByteReader returns a ByteReader reading from r.
+func NewByteReader(r *Reader[byte]) *ByteReader {
+	return &ByteReader{Reader: r}
+}
+
+// Read reads data into p, see Reader.Read.
+func (b *ByteReader) Read(p []byte) (int, error) {
+	var n int
+	var err error
+	if b.npend > 0 {
+		n = copy(p, b.pend[:b.npend])
+		b.consume(n)
+	} else {
+		n, err = b.Reader.Read(p)
+	}
+	b.setLast(p[:n], false)
+	return n, err
+}
+
+// ReadByte reads and returns a single byte.
+func (b *ByteReader) ReadByte() (byte, error) {
+	var c byte
+	var err error
+	if b.npend > 0 {
+		c = b.pend[0]
+		b.consume(1)
+	} else if c, err = b.Reader.ReadOne(); err != nil {
+		b.setLast(nil, false)
+		return c, err
+	}
+	b.setLast([]byte{c}, false)
+	return c, nil
+}
+
+// UnreadByte unreads the last byte read by the previous read operation, so
+// it is returned again by the next read.
+func (b *ByteReader) UnreadByte() error {
+	if b.nlast == 0 {
+		return bufio.ErrInvalidUnreadByte
+	}
+	b.unread(b.last[b.nlast-1 : b.nlast])
+	return nil
+}
+
+// UnreadRune unreads the last rune read by ReadRune, so it is returned again
+// by the next read.
+func (b *ByteReader) UnreadRune() error {
+	if !b.lastRune {
+		return bufio.ErrInvalidUnreadRune
+	}
+	b.unread(b.last[:b.nlast])
+	return nil
+}
+
+// setLast records the bytes returned by a read, keeping only the last one
+// unless they form a rune.
+func (b *ByteReader) setLast(p []byte, isRune bool) {
+	if !isRune && len(p) > 1 {
+		p = p[len(p)-1:]
+	}
+	b.nlast = copy(b.last[:], p)
+	b.lastRune = isRune
+}
+
+// unread puts back p, the bytes returned by the last read, in front of the
+// pending bytes. Those bytes were taken from the pending bytes or read while
+// there were none, so they always fit.
+func (b *ByteReader) unread(p []byte) {
+	copy(b.pend[len(p):], b.pend[:b.npend])
+	copy(b.pend[:], p)
+	b.npend += len(p)
+	b.setLast(nil, false)
+}
+
+// ReadRune reads a single UTF-8 encoded character and returns the rune and
+// its size in bytes. Invalid encodings are returned as utf8.RuneError with a
+// size of 1. If only part of a character is available, the partial data is
+// kept and io.EOF is returned; a later call will return the whole character.
+// Once the writer is closed, the partial data is returned as utf8.RuneError,
+// one byte at a time, like bufio.Reader does.
+func (b *ByteReader) ReadRune() (rune, int, error) {
+	for !utf8.FullRune(b.pend[:b.npend]) {
+		c, err := b.Reader.ReadOne()
+		if err != nil {
+			if err == io.EOF && b.npend > 0 && b.w.isClosed() {
+				// the character will never be completed
+				break
+			}
+			b.setLast(nil, false)
+			return 0, 0, err
+		}
+		if b.npend == 0 && c < utf8.RuneSelf {
+			b.setLast([]byte{c}, true)
+			return rune(c), 1, nil
+		}
+		b.pend[b.npend] = c
+		b.npend += 1
+	}
+
+	r, size := utf8.DecodeRune(b.pend[:b.npend])
+	b.setLast(b.pend[:size], true)
+	b.consume(size)
+	return r, size, nil
+}
+
+// ReadText is like Read, but never splits a UTF-8 encoded character across
+// calls: if the data read ends with an incomplete character, those bytes are
+// kept and returned by a later call along with the rest of the character,
+// unless the writer was closed. p must be at least utf8.UTFMax bytes long.
+func (b *ByteReader) ReadText(p []byte) (int, error) {
+	if len(p) < utf8.UTFMax {
+		return 0, io.ErrShortBuffer
+	}
+	b.setLast(nil, false)
+
+	for {
+		n := copy(p, b.pend[:b.npend])
+		b.npend = 0
+		m, err := b.Reader.Read(p[n:])
+		n += m
+		if m == 0 {
+			if n > 0 && b.w.isClosed() {
+				// the character will never be completed
+				return n, nil
+			}
+			b.npend = copy(b.pend[:], p[:n])
+			return 0, err
+		}
+
+		end := n - incompleteRune(p[:n])
+		b.npend = copy(b.pend[:], p[end:n])
+		if end > 0 {
+			return end, nil
+		}
+	}
+}
+
+// incompleteRune returns the length of the incomplete UTF-8 encoded
+// character at the end of p, if any.
+func incompleteRune(p []byte) int {
+	for i := len(p) - 1; i >= max(len(p)-utf8.UTFMax+1, 0); i-- {
+		if utf8.RuneStart(p[i]) {
+			if utf8.FullRune(p[i:]) {
+				return 0
+			}
+			return len(p) - i
+		}
+	}
+	return 0
+}
+
+// ReadBytes reads until the first occurrence of delim and returns a slice
+// containing the data up to and including the delimiter. If the reader is
+// blocking, ReadBytes waits until the delimiter is written. Otherwise, if the
+// delimiter isn't available yet, the partial record is kept and io.EOF is
+// returned; a later call will return the whole record.
+//
+// Once the writer has been closed, the remaining data is returned with
+// io.EOF even if it does not end in delim. Other errors, such as
+// ErrStaleReader, are returned along with the partial record.
+func (b *ByteReader) ReadBytes(delim byte) ([]byte, error) {
+	b.setLast(nil, false)
+	for b.npend > 0 {
+		c := b.pend[0]
+		b.consume(1)
+		b.line = append(b.line, c)
+		if c == delim {
+			return b.takeLine(), nil
+		}
+	}
+
+	r := b.Reader
+	for {
+		if len(r.ahead) == 0 {
+			if r.isClosed() {
+				return nil, io.ErrClosedPipe
+			}
+			err := r.fill()
+			if err == io.EOF && (len(b.line) == 0 || !r.w.isClosed()) {
+				return nil, err
+			}
+			if err != nil {
+				return b.takeLine(), err
+			}
+		}
+
+		if i := bytes.IndexByte(r.ahead, delim); i >= 0 {
+			b.line = append(b.line, r.ahead[:i+1]...)
+			r.ahead = r.ahead[i+1:]
+			return b.takeLine(), nil
+		}
+		b.line = append(b.line, r.ahead...)
+		r.ahead = nil
+	}
+}
+
+// ReadString is like ReadBytes but returns a string.
+func (b *ByteReader) ReadString(delim byte) (string, error) {
+	line, err := b.ReadBytes(delim)
+	return string(line), err
+}
+
+func (b *ByteReader) takeLine() []byte {
+	line := b.line
+	b.line = nil
+	return line
+}
+
+// Reset sets the reader's position after the writer's latest write, see
+// Reader.Reset.
+func (b *ByteReader) Reset() {
+	b.npend = 0
+	b.line = nil
+	b.setLast(nil, false)
+	b.Reader.Reset()
+}
+
+func (b *ByteReader) consume(n int) {
+	copy(b.pend[:], b.pend[n:b.npend])
+	b.npend -= n
+}
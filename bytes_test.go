@@ -0,0 +1,239 @@
+package ringslice
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"unicode/utf8"
+)
+
+var (
+	_ io.Writer       = (*ByteRing)(nil)
+	_ io.StringWriter = (*ByteRing)(nil)
+	_ io.ByteWriter   = (*ByteRing)(nil)
+	_ io.ByteScanner  = (*ByteReader)(nil)
+	_ io.RuneScanner  = (*ByteReader)(nil)
+)
+
+func TestByteRing(t *testing.T) {
+	rbuf := make([]byte, 32)
+
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := b.Reader()
+
+	fmt.Fprintf(b, "n=%d ", 42)
+	b.WriteString("str")
+	b.WriteByte('!')
+
+	n, err := r.Read(rbuf)
+	if err != nil || string(rbuf[:n]) != "n=42 str!" {
+		t.Errorf("failed ByteRing test, expected n=42 str!, got %q err=%v", rbuf[:n], err)
+	}
+}
+
+func TestByteReader(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := NewByteReader(b.Reader())
+
+	// "aé€" with the last character written in two parts
+	b.WriteString("aé\xe2\x82")
+
+	c, err := r.ReadByte()
+	if c != 'a' || err != nil {
+		t.Errorf("failed ReadByte test, expected a, got %q err=%v", c, err)
+	}
+
+	ch, size, err := r.ReadRune()
+	if ch != 'é' || size != 2 || err != nil {
+		t.Errorf("failed ReadRune test, expected é, got %q size=%d err=%v", ch, size, err)
+	}
+
+	ch, size, err = r.ReadRune()
+	if err != io.EOF {
+		t.Errorf("failed partial ReadRune test, expected io.EOF, got %q size=%d err=%v", ch, size, err)
+	}
+
+	b.WriteByte('\xac')
+
+	ch, size, err = r.ReadRune()
+	if ch != '€' || size != 3 || err != nil {
+		t.Errorf("failed ReadRune test, expected €, got %q size=%d err=%v", ch, size, err)
+	}
+
+	if err := r.UnreadRune(); err != nil {
+		t.Errorf("failed UnreadRune test, expected nil, got %v", err)
+	}
+	if err := r.UnreadRune(); err == nil {
+		t.Errorf("failed double UnreadRune test, expected error")
+	}
+	if c, err := r.ReadByte(); c != '\xe2' || err != nil {
+		t.Errorf("failed unread ReadByte test, expected 0xe2, got %q err=%v", c, err)
+	}
+	if err := r.UnreadByte(); err != nil {
+		t.Errorf("failed UnreadByte test, expected nil, got %v", err)
+	}
+	if ch, _, err := r.ReadRune(); ch != '€' || err != nil {
+		t.Errorf("failed unread ReadRune test, expected €, got %q err=%v", ch, err)
+	}
+}
+
+func TestReadRuneClosed(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := NewByteReader(b.Reader())
+	defer r.Close()
+
+	// an incomplete "€" followed by the end of data
+	b.WriteString("\xe2\x82")
+	if _, _, err := r.ReadRune(); err != io.EOF {
+		t.Errorf("failed partial ReadRune test, expected io.EOF, got %v", err)
+	}
+	b.closeWithError(nil)
+
+	for i := 0; i < 2; i++ {
+		if ch, size, err := r.ReadRune(); ch != utf8.RuneError || size != 1 || err != nil {
+			t.Errorf("failed closed ReadRune test, expected RuneError, got %q size=%d err=%v", ch, size, err)
+		}
+	}
+	if _, _, err := r.ReadRune(); err != io.EOF {
+		t.Errorf("failed closed ReadRune test, expected io.EOF, got %v", err)
+	}
+}
+
+func TestReadString(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := NewByteReader(b.Reader())
+
+	b.WriteString("hello\nwor")
+
+	s, err := r.ReadString('\n')
+	if s != "hello\n" || err != nil {
+		t.Errorf("failed ReadString test, expected hello, got %q err=%v", s, err)
+	}
+
+	s, err = r.ReadString('\n')
+	if s != "" || err != io.EOF {
+		t.Errorf("failed partial ReadString test, expected io.EOF, got %q err=%v", s, err)
+	}
+
+	b.WriteString("ld\nlast")
+
+	s, err = r.ReadString('\n')
+	if s != "world\n" || err != nil {
+		t.Errorf("failed ReadString test, expected world, got %q err=%v", s, err)
+	}
+
+	// blocking reader
+	br := NewByteReader(b.BlockingCurrentReader())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		s, err := br.ReadString('\n')
+		if s != "foo\n" || err != nil {
+			t.Errorf("failed blocking ReadString test, expected foo, got %q err=%v", s, err)
+		}
+		s, err = br.ReadString('\n')
+		if s != "bar" || err != io.EOF {
+			t.Errorf("failed ReadString after close test, expected bar, got %q err=%v", s, err)
+		}
+		br.Close()
+	}()
+
+	b.WriteString("fo")
+	b.WriteString("o\nbar")
+	r.Close()
+	b.Close()
+	<-done
+}
+
+func TestSnapshotReaderAt(t *testing.T) {
+	b, err := NewByteRing(8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	b.WriteString("hello world")
+	s := b.SnapshotReaderAt()
+	b.WriteString("!!!")
+
+	rbuf := make([]byte, 4)
+	n, err := s.ReadAt(rbuf, 3)
+	if string(rbuf[:n]) != "worl" || err != nil {
+		t.Errorf("failed ReadAt test, expected worl, got %q err=%v", rbuf[:n], err)
+	}
+
+	s.Seek(-2, io.SeekEnd)
+	all, _ := io.ReadAll(s)
+	if string(all) != "ld" {
+		t.Errorf("failed Seek test, expected ld, got %q", all)
+	}
+}
+
+func TestReadText(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := NewByteReader(b.Reader())
+	defer r.Close()
+	buf := make([]byte, 8)
+
+	// "é" is 2 bytes, "€" is 3 bytes
+	b.WriteString("aé€")
+	b.Write([]byte("€")[:1])
+	n, err := r.ReadText(buf)
+	if err != nil || string(buf[:n]) != "aé€" {
+		t.Errorf("failed ReadText test, expected aé€, got %q err=%v", buf[:n], err)
+	}
+
+	b.Write([]byte("€")[1:2])
+	if n, err := r.ReadText(buf); n != 0 || err != io.EOF {
+		t.Errorf("failed ReadText partial test, expected io.EOF, got %d err=%v", n, err)
+	}
+	b.Write([]byte("€")[2:])
+	b.WriteString("b")
+	n, err = r.ReadText(buf)
+	if err != nil || string(buf[:n]) != "€b" {
+		t.Errorf("failed ReadText test, expected €b, got %q err=%v", buf[:n], err)
+	}
+
+	if _, err := r.ReadText(buf[:2]); err != io.ErrShortBuffer {
+		t.Errorf("failed ReadText short buffer test, expected io.ErrShortBuffer, got %v", err)
+	}
+
+	// an incomplete character is returned once the writer is closed
+	b.Write([]byte("é")[:1])
+	if n, err := r.ReadText(buf); n != 0 || err != io.EOF {
+		t.Errorf("failed ReadText partial test, expected io.EOF, got %d err=%v", n, err)
+	}
+	b.closeWithError(nil)
+	if n, err := r.ReadText(buf); n != 1 || err != nil {
+		t.Errorf("failed ReadText close test, expected 1 byte, got %d err=%v", n, err)
+	}
+	if _, err := r.ReadText(buf); err != io.EOF {
+		t.Errorf("failed ReadText close test, expected io.EOF, got %v", err)
+	}
+}
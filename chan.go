@@ -0,0 +1,88 @@
+package ringslice
+
+import "context"
+
+// maxChanBatch is the maximum number of elements FromChan writes at once
+const maxChanBatch = 64
+
+// FromChan starts a goroutine writing the values received from ch to w until
+// ch is closed, which closes w without waiting for its readers, or until ctx
+// is cancelled. Values available at once on ch are written together. The
+// returned channel receives the error that stopped the goroutine (nil if ch
+// was closed, or the context's error) and is then closed.
+func FromChan[T any](ctx context.Context, w *Writer[T], ch <-chan T) <-chan error {
+	res := make(chan error, 1)
+	go func() {
+		defer close(res)
+		res <- fromChan(ctx, w, ch)
+	}()
+	return res
+}
+
+func fromChan[T any](ctx context.Context, w *Writer[T], ch <-chan T) error {
+	batch := make([]T, 0, maxChanBatch)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case v, ok := <-ch:
+			if !ok {
+				w.closeWithError(nil)
+				return nil
+			}
+			batch = append(batch[:0], v)
+		}
+
+		// collect what is immediately available
+		open := true
+	collect:
+		for open && len(batch) < maxChanBatch {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					open = false
+					break collect
+				}
+				batch = append(batch, v)
+			default:
+				break collect
+			}
+		}
+
+		if _, err := w.Write(batch); err != nil {
+			return err
+		}
+		if !open {
+			w.closeWithError(nil)
+			return nil
+		}
+	}
+}
+
+// ToChan starts a goroutine sending the elements read from r to the returned
+// channel, and takes ownership of r. Once r returns an error (such as io.EOF
+// for a blocking reader whose writer was closed) or ctx is cancelled, r is
+// closed and so is the channel. Use a blocking reader with auto skip enabled
+// to follow a writer indefinitely.
+func ToChan[T any](ctx context.Context, r *Reader[T]) <-chan T {
+	res := make(chan T)
+	stop := context.AfterFunc(ctx, func() { r.Close() })
+	go func() {
+		defer close(res)
+		defer stop()
+		defer r.Close()
+
+		for {
+			v, err := r.ReadOne()
+			if err != nil {
+				return
+			}
+			select {
+			case res <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res
+}
@@ -0,0 +1,90 @@
+package ringslice
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestChan(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.BlockingReader()
+	out := ToChan(context.Background(), r)
+
+	in := make(chan int, 4)
+	done := FromChan(context.Background(), w, in)
+	for i := 1; i <= 10; i++ {
+		in <- i
+	}
+	close(in)
+
+	var res []int
+	for v := range out {
+		res = append(res, v)
+	}
+	if !slices.Equal(res, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Errorf("failed channel bridge test, got %v", res)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("failed channel bridge test, expected nil error, got %v", err)
+	}
+}
+
+func TestFromChanOpenReader(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	// a reader that is never drained must not prevent FromChan from returning
+	r := w.BlockingReader()
+	defer r.Close()
+
+	in := make(chan int, 1)
+	in <- 1
+	close(in)
+	select {
+	case err := <-FromChan(context.Background(), w, in):
+		if err != nil {
+			t.Errorf("failed open reader test, expected nil error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed open reader test, FromChan did not return")
+	}
+	if !w.isClosed() {
+		t.Errorf("failed open reader test, writer should be closed")
+	}
+}
+
+func TestChanCancel(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := ToChan(ctx, w.BlockingReader())
+	done := FromChan(ctx, w, make(chan int))
+
+	cancel()
+	if _, ok := <-out; ok {
+		t.Errorf("failed channel cancel test, expected closed channel")
+	}
+	if err := <-done; err != context.Canceled {
+		t.Errorf("failed channel cancel test, expected context.Canceled, got %v", err)
+	}
+	if w.isClosed() {
+		t.Errorf("failed channel cancel test, writer should not be closed")
+	}
+	if s := w.Stats(); s.Readers != 0 {
+		t.Errorf("failed channel cancel test, expected reader to be closed, got %d", s.Readers)
+	}
+}
@@ -0,0 +1,182 @@
+package ringslice
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// CheckpointOptions defines when a Checkpointer saves the buffer.
+type CheckpointOptions struct {
+	Interval time.Duration // save every Interval if data was written, 0 to disable
+	Every    int64         // save once Every elements were written, 0 to disable
+}
+
+// Checkpointer periodically saves the state of a Writer to a file in the
+// background, see StartCheckpointer.
+type Checkpointer[T any] struct {
+	w    *Writer[T]
+	path string
+	enc  func(T) ([]byte, error)
+	opts CheckpointOptions
+
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+
+	mu   sync.Mutex // serializes checkpoints
+	last int64      // TotalWritten at the last checkpoint
+	err  error
+}
+
+// StartCheckpointer starts a goroutine saving the state of w to path (see
+// SaveState) as defined by opts. The file is replaced atomically, so a crash
+// while saving leaves the previous checkpoint in place. A checkpoint can be
+// restored with LoadCheckpoint.
+func StartCheckpointer[T any](w *Writer[T], path string, enc func(T) ([]byte, error), opts CheckpointOptions) *Checkpointer[T] {
+	c := &Checkpointer[T]{
+		w:      w,
+		path:   path,
+		enc:    enc,
+		opts:   opts,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		last:   -1,
+	}
+
+	if opts.Every > 0 {
+		w.watch(c.notify)
+	}
+	go c.run()
+
+	return c
+}
+
+// LoadCheckpoint restores a buffer saved by a Checkpointer.
+func LoadCheckpoint[T any](path string, dec func([]byte) (T, error)) (*Writer[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadState(f, dec)
+}
+
+func (c *Checkpointer[T]) run() {
+	defer close(c.done)
+
+	// tick is fed by a timer re-armed after each periodic checkpoint
+	clock := c.w.clk()
+	tick := make(chan struct{}, 1)
+	var timer Timer
+	arm := func() {
+		if c.opts.Interval > 0 {
+			timer = clock.AfterFunc(c.opts.Interval, func() { tick <- struct{}{} })
+		}
+	}
+	arm()
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-tick:
+			c.save(false)
+			arm()
+		case <-c.notify:
+			if c.w.TotalWritten()-c.lastSaved() >= c.opts.Every {
+				c.save(false)
+			}
+		}
+	}
+}
+
+func (c *Checkpointer[T]) lastSaved() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return max(c.last, 0)
+}
+
+// Checkpoint saves the buffer immediately.
+func (c *Checkpointer[T]) Checkpoint() error {
+	return c.save(true)
+}
+
+// save writes a checkpoint, unless nothing was written since the last one
+// and force is false.
+func (c *Checkpointer[T]) save(force bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	written := c.w.TotalWritten()
+	if written == c.last && !force {
+		return nil
+	}
+
+	err := writeFileAtomic(c.path, func(out io.Writer) error {
+		return c.w.SaveState(out, c.enc)
+	})
+	if err == nil {
+		c.last = written
+	}
+	c.err = err
+	return err
+}
+
+// Err returns the error of the last checkpoint attempt, if it failed.
+func (c *Checkpointer[T]) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// Stop stops the checkpointer after saving a final checkpoint.
+func (c *Checkpointer[T]) Stop() error {
+	if c.opts.Every > 0 {
+		c.w.unwatch(c.notify)
+	}
+	close(c.stop)
+	<-c.done
+
+	return c.save(false)
+}
+
+// writeFileAtomic writes a file through a temporary file renamed once
+// complete.
+func writeFileAtomic(path string, fn func(io.Writer) error) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(f)
+	err = fn(out)
+	if err == nil {
+		err = out.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package ringslice
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCheckpointer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	enc := func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil }
+	dec := func(b []byte) (int, error) { return strconv.Atoi(string(b)) }
+
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	c := StartCheckpointer(w, path, enc, CheckpointOptions{Every: 3})
+	w.Append(1, 2, 3)
+
+	// wait for the checkpoint to be written
+	var w2 *Writer[int]
+	for i := 0; i < 100; i++ {
+		if w2, err = LoadCheckpoint(path, dec); err == nil {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err != nil || !slices.Equal(w2.Snapshot(), []int{1, 2, 3}) {
+		t.Errorf("failed checkpoint test, got err=%v", err)
+	}
+
+	w.Append(4, 5)
+	if err := c.Stop(); err != nil {
+		t.Errorf("failed checkpoint stop test, got err=%v", err)
+	}
+
+	w2, err = LoadCheckpoint(path, dec)
+	if err != nil || !slices.Equal(w2.Snapshot(), []int{2, 3, 4, 5}) || w2.TotalWritten() != 5 {
+		t.Errorf("failed final checkpoint test, got err=%v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package ringslice
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock provides the time to the time based features of a Writer and its
+// readers: timestamps, expiration, deadlines, rate limits and checkpoint
+// intervals. It can be replaced with SetClock, typically by a ManualClock in
+// tests.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine after d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the timer from firing, and returns false if it already
+	// fired or was stopped.
+	Stop() bool
+}
+
+// systemClock is the Clock using the time package
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// SetClock sets the clock used by the buffer and its readers. A nil clock
+// restores the system clock.
+func (w *Writer[T]) SetClock(c Clock) {
+	if c == nil {
+		w.clock.Store(nil)
+		return
+	}
+	w.clock.Store(&c)
+}
+
+// clk returns the clock of the buffer.
+func (w *Writer[T]) clk() Clock {
+	if c := w.clock.Load(); c != nil {
+		return *c
+	}
+	return systemClock{}
+}
+
+// sleep waits for d on clock c.
+func sleep(c Clock, d time.Duration) {
+	ch := make(chan struct{})
+	c.AfterFunc(d, func() { close(ch) })
+	<-ch
+}
+
+// ManualClock is a Clock whose time only changes when Advance or Set is
+// called, allowing tests to control time deterministically.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	c  *ManualClock
+	at time.Time
+	f  func()
+}
+
+// NewManualClock returns a new ManualClock set to t.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t}
+}
+
+// Now returns the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc calls f in its own goroutine once the clock has been advanced by
+// at least d.
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	t := &manualTimer{c: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+
+	// fire timers which are already due
+	c.Advance(0)
+	return t
+}
+
+// Advance moves the clock forward by d, firing the timers that become due.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.fire()
+	c.mu.Unlock()
+}
+
+// Set sets the clock to t, firing the timers that become due. Setting a
+// time in the past is allowed, to simulate clock adjustments.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.fire()
+	c.mu.Unlock()
+}
+
+// fire starts due timers. The caller must hold the lock.
+func (c *ManualClock) fire() {
+	c.timers = slices.DeleteFunc(c.timers, func(t *manualTimer) bool {
+		if t.at.After(c.now) {
+			return false
+		}
+		go t.f()
+		return true
+	})
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := len(c.timers)
+	c.timers = slices.DeleteFunc(c.timers, func(o *manualTimer) bool { return o == t })
+	return len(c.timers) != n
+}
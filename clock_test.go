@@ -0,0 +1,68 @@
+package ringslice
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.SetClock(clock)
+	w.SetMaxAge(time.Minute)
+
+	w.Append(1)
+	clock.Advance(30 * time.Second)
+	w.WriteTTL([]int{2}, 10*time.Second)
+	w.Append(3)
+
+	if s := w.Snapshot(); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("failed manual clock test, expected [1 2 3], got %v", s)
+	}
+	clock.Advance(31 * time.Second)
+	if s := w.Snapshot(); !slices.Equal(s, []int{3}) {
+		t.Errorf("failed manual clock expiration test, expected [3], got %v", s)
+	}
+
+	// timestamps never go backward
+	now := clock.Now()
+	clock.Set(now.Add(-time.Hour))
+	w.Append(4)
+	clock.Set(now)
+
+	r := w.Reader()
+	r.SeekToTime(now.Add(-31 * time.Second))
+	buf := make([]int, 8)
+	if n, _ := r.Read(buf); !slices.Equal(buf[:n], []int{3, 4}) {
+		t.Errorf("failed manual clock backward test, expected [3 4], got %v", buf[:n])
+	}
+	r.Close()
+
+	// deadlines fire when the clock is advanced
+	r = w.BlockingCurrentReader()
+	defer r.Close()
+	r.SetReadDeadline(clock.Now().Add(time.Second))
+
+	res := make(chan error)
+	go func() {
+		_, err := r.Read(make([]int, 1))
+		res <- err
+	}()
+
+	select {
+	case err := <-res:
+		t.Errorf("failed manual clock deadline test, read returned early with %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	clock.Advance(time.Second)
+	if err := <-res; err != os.ErrDeadlineExceeded {
+		t.Errorf("failed manual clock deadline test, expected os.ErrDeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package ringslice
+
+// Equal reports whether a and b are equal, and can be passed to SetCollapse
+// for comparable element types.
+func Equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+// SetCollapse enables collapsing of consecutive identical elements: a write
+// of an element equal to the most recent one, according to eq, does not
+// store it again but increments the number of times the most recent element
+// was written (see Repeats). A nil eq disables collapsing. Readers which
+// already read the most recent element will not see its count change.
+func (w *Writer[T]) SetCollapse(eq func(a, b T) bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.collapse = eq
+	if eq == nil {
+		w.repeats = nil
+		return
+	}
+	if w.repeats == nil {
+		pageSize := int64(0)
+		if w.data.lazy {
+			pageSize = w.data.pageSize
+		}
+		repeats := newStorage[int64](w.size, pageSize)
+		w.repeats = &repeats
+	}
+}
+
+// writeCollapsed writes values one run of identical elements at a time.
+// The caller must hold the lock.
+func (w *Writer[T]) writeCollapsed(values []T) (int, error) {
+	if err := w.reserve(min(len(values), 1)); err != nil {
+		return 0, err
+	}
+	w.writes++
+	w.wsizes.add(len(values))
+
+	n := 0
+	for n < len(values) {
+		c := 1
+		for n+c < len(values) && w.collapse(values[n], values[n+c]) {
+			c++
+		}
+
+		head := w.head.Load()
+		if head > w.oldest(head) && w.collapse(w.data.at(head-1), values[n]) {
+			w.repeats.set(head-1, w.repeats.at(head-1)+int64(c))
+		} else {
+			if err := w.reserve(1); err != nil {
+				return n, err
+			}
+			head = w.head.Load()
+			w.write(values[n : n+1])
+			w.repeats.set(head, int64(c))
+			if h := w.instrument(); h != nil {
+				h.OnWrite(1)
+			}
+			w.broadcast()
+		}
+		n += c
+	}
+	return n, nil
+}
+
+// Repeats returns the number of consecutive writes collapsed into the
+// element at absolute position pos, or 0 if it is not retained anymore. It
+// returns 1 for retained elements if collapsing is not enabled.
+func (w *Writer[T]) Repeats(pos int64) int64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head := w.head.Load()
+	if pos < w.oldest(head) || pos >= head {
+		return 0
+	}
+	if w.repeats == nil {
+		return 1
+	}
+	return max(w.repeats.at(pos), 1)
+}
+
+// ReadRepeated reads a single element like ReadOne, and returns the number
+// of consecutive writes collapsed into it (see SetCollapse).
+func (r *Reader[T]) ReadRepeated() (T, int64, error) {
+	v, err := r.ReadOne()
+	if err != nil {
+		return v, 0, err
+	}
+	pos := r.pos.Load() - int64(len(r.ahead)) - 1
+	return v, max(r.w.Repeats(pos), 1), nil
+}
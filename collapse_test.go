@@ -0,0 +1,51 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollapse(t *testing.T) {
+	w, err := New[string](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append("a")
+	w.SetCollapse(Equal[string])
+	w.Append("a", "a", "b")
+	w.Append("b")
+	w.Append("c", "d", "d", "d")
+
+	if s := w.Snapshot(); !slices.Equal(s, []string{"a", "b", "c", "d"}) {
+		t.Errorf("failed collapse test, expected [a b c d], got %v", s)
+	}
+	if n, err := w.Append("d", "e"); n != 2 || err != nil {
+		t.Errorf("failed collapse write test, expected 2, got %d err=%v", n, err)
+	}
+
+	r := w.Reader()
+	defer r.Close()
+
+	var counts []int64
+	for {
+		_, c, err := r.ReadRepeated()
+		if err != nil {
+			break
+		}
+		counts = append(counts, c)
+	}
+	if !slices.Equal(counts, []int64{2, 1, 4, 1}) {
+		t.Errorf("failed collapse repeats test, expected [2 1 4 1], got %v", counts)
+	}
+	if c := w.Repeats(0); c != 0 {
+		t.Errorf("failed overwritten repeats test, expected 0, got %d", c)
+	}
+
+	w.SetCollapse(nil)
+	w.Append("e")
+	if c := w.Repeats(w.TotalWritten() - 1); c != 1 {
+		t.Errorf("failed collapse disable test, expected 1, got %d", c)
+	}
+}
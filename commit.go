@@ -0,0 +1,34 @@
+package ringslice
+
+// CommitHook is called when a consumer commits its progress, either with
+// ConsumerGroup.Commit or Reader.SavePosition, so external systems can record
+// it together with their own side effects. The offset is the sequence number
+// of the first element not consumed yet, and name is the name of the group or
+// reader. If OnCommit returns an error, the commit is not applied and the
+// error is returned to the consumer.
+type CommitHook interface {
+	OnCommit(name string, offset int64) error
+}
+
+// CommitFunc is a function implementing CommitHook.
+type CommitFunc func(name string, offset int64) error
+
+func (f CommitFunc) OnCommit(name string, offset int64) error { return f(name, offset) }
+
+// SetCommitHook sets the hook called when consumers of the buffer commit
+// their progress, or removes it if h is nil.
+func (w *Writer[T]) SetCommitHook(h CommitHook) {
+	if h == nil {
+		w.commit.Store(nil)
+		return
+	}
+	w.commit.Store(&h)
+}
+
+// onCommit calls the commit hook, if any.
+func (w *Writer[T]) onCommit(name string, offset int64) error {
+	if h := w.commit.Load(); h != nil {
+		return (*h).OnCommit(name, offset)
+	}
+	return nil
+}
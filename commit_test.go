@@ -0,0 +1,52 @@
+package ringslice
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommitHook(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	var names []string
+	var offsets []int64
+	fail := errors.New("transaction failed")
+	w.SetCommitHook(CommitFunc(func(name string, offset int64) error {
+		if offset == 3 {
+			return fail
+		}
+		names = append(names, name)
+		offsets = append(offsets, offset)
+		return nil
+	}))
+
+	w.Append(1, 2, 3, 4)
+
+	g := w.ConsumerGroup("group")
+	defer g.Close()
+	for i := 0; i < 3; i++ {
+		g.Next()
+	}
+	g.Commit(0)
+	if err := g.Commit(2); err != fail {
+		t.Errorf("failed commit hook veto test, expected error, got %v", err)
+	}
+	if g.Committed() != 1 {
+		t.Errorf("failed commit hook veto test, expected offset 1, got %d", g.Committed())
+	}
+
+	r, _ := w.ResumeReader("reader", NewFilePositionStore(filepath.Join(t.TempDir(), "pos")))
+	defer r.Close()
+	r.ReadOne()
+	r.ReadOne()
+	r.SavePosition()
+
+	if len(names) != 2 || names[0] != "group" || offsets[0] != 1 || names[1] != "reader" || offsets[1] != 2 {
+		t.Errorf("failed commit hook test, got %v %v", names, offsets)
+	}
+}
@@ -0,0 +1,84 @@
+package ringslice
+
+import (
+	"io"
+	"net"
+	"time"
+)
+
+// Conn is an in-memory net.Conn backed by a pair of byte buffers, one for
+// each direction, see ConnPair.
+type Conn struct {
+	in  *PipeReader[byte]
+	out *PipeWriter[byte]
+}
+
+// ConnPair returns two connected in-memory connections, each direction
+// buffered by a ring of the given size. Unlike net.Pipe, writes only block
+// when the buffer is full. Data written to one end can be read from the other
+// end, and closing one end causes reads on the other end to return io.EOF
+// once all data has been read, and writes to fail with io.ErrClosedPipe.
+func ConnPair(size int64) (*Conn, *Conn, error) {
+	r1, w1, err := Pipe[byte](size)
+	if err != nil {
+		return nil, nil, err
+	}
+	r2, w2, err := Pipe[byte](size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &Conn{in: r1, out: w2}, &Conn{in: r2, out: w1}, nil
+}
+
+// Read reads data from the connection.
+func (c *Conn) Read(b []byte) (int, error) {
+	return c.in.Read(b)
+}
+
+// Write writes data to the connection.
+func (c *Conn) Write(b []byte) (int, error) {
+	return c.out.Write(b)
+}
+
+// Close closes the connection. Pending reads and writes on this end are
+// unblocked and return io.ErrClosedPipe.
+func (c *Conn) Close() error {
+	c.out.Close()
+	c.in.CloseWithError(io.ErrClosedPipe)
+	return nil
+}
+
+// LocalAddr returns the local network address.
+func (c *Conn) LocalAddr() net.Addr {
+	return connAddr{}
+}
+
+// RemoteAddr returns the remote network address.
+func (c *Conn) RemoteAddr() net.Addr {
+	return connAddr{}
+}
+
+// SetDeadline sets both the read and write deadlines.
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.SetReadDeadline(t)
+	c.SetWriteDeadline(t)
+	return nil
+}
+
+// SetReadDeadline sets the deadline for future and pending Read calls.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.in.r.SetReadDeadline(t)
+	return nil
+}
+
+// SetWriteDeadline sets the deadline for future and pending Write calls.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.out.w.SetWriteDeadline(t)
+	return nil
+}
+
+type connAddr struct{}
+
+func (connAddr) Network() string { return "ringslice" }
+func (connAddr) String() string  { return "ringslice" }
@@ -0,0 +1,72 @@
+package ringslice
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+var _ net.Conn = (*Conn)(nil)
+
+func TestConn(t *testing.T) {
+	rbuf := make([]byte, 32)
+
+	c1, c2, err := ConnPair(8)
+	if err != nil {
+		t.Errorf("failed to initialize connection")
+		return
+	}
+
+	c1.Write([]byte("ping"))
+	n, err := c2.Read(rbuf)
+	if string(rbuf[:n]) != "ping" || err != nil {
+		t.Errorf("failed conn test, expected ping, got %q err=%v", rbuf[:n], err)
+	}
+
+	c2.Write([]byte("pong"))
+	n, err = c1.Read(rbuf)
+	if string(rbuf[:n]) != "pong" || err != nil {
+		t.Errorf("failed conn test, expected pong, got %q err=%v", rbuf[:n], err)
+	}
+
+	// read deadline
+	c1.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	_, err = c1.Read(rbuf)
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("failed conn read deadline test, got err=%v", err)
+	}
+
+	// write deadline, the buffer is full after 8 bytes
+	c1.SetWriteDeadline(time.Now().Add(10 * time.Millisecond))
+	n, err = c1.Write([]byte("0123456789"))
+	if n != 8 || !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("failed conn write deadline test, got n=%d err=%v", n, err)
+	}
+
+	// close unblocks pending reads
+	c1.SetReadDeadline(time.Time{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c1.Close()
+	}()
+	_, err = c1.Read(rbuf)
+	if err != io.ErrClosedPipe {
+		t.Errorf("failed conn close test, expected io.ErrClosedPipe, got err=%v", err)
+	}
+
+	n, err = c2.Read(rbuf)
+	if string(rbuf[:n]) != "01234567" || err != nil {
+		t.Errorf("failed conn test, expected 01234567, got %q err=%v", rbuf[:n], err)
+	}
+	_, err = c2.Read(rbuf)
+	if err != io.EOF {
+		t.Errorf("failed conn close test, expected io.EOF, got err=%v", err)
+	}
+	_, err = c2.Write([]byte("x"))
+	if err != io.ErrClosedPipe {
+		t.Errorf("failed conn close test, expected io.ErrClosedPipe, got err=%v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package ringslice
+
+import "io"
+
+// copyBufferSize is the number of elements Copy moves at once
+const copyBufferSize = 4096
+
+// SliceWriter is implemented by anything accepting slices of T, such as a
+// Writer, or any io.Writer when T is byte.
+type SliceWriter[T any] interface {
+	Write([]T) (int, error)
+}
+
+// SliceReader is implemented by anything providing slices of T, such as a
+// Reader, or any io.Reader when T is byte.
+type SliceReader[T any] interface {
+	Read([]T) (int, error)
+}
+
+// Copy copies data from src to dst until src returns io.EOF, which for a
+// blocking reader means the writer was closed. It returns the number of
+// elements copied and the first error encountered, if any, io.EOF not being
+// considered an error.
+//
+// If src falls behind and becomes stale, Copy resumes from the oldest data
+// still available and reports the number of missed elements to onSkip. If
+// onSkip is nil, Copy returns ErrStaleReader instead.
+func Copy[T any](dst SliceWriter[T], src *Reader[T], onSkip func(missed int64)) (int64, error) {
+	return copyN(dst, src, -1, onSkip)
+}
+
+// CopyN copies n elements (or until an error) from src to dst, and returns
+// the number of elements copied. On return, written == n if and only if err
+// == nil. Stale readers are handled like Copy does.
+func CopyN[T any](dst SliceWriter[T], src *Reader[T], n int64, onSkip func(missed int64)) (int64, error) {
+	written, err := copyN(dst, src, n, onSkip)
+	if written < n && err == nil {
+		err = io.EOF
+	}
+	return written, err
+}
+
+// copyN copies up to n elements, or everything if n is negative.
+func copyN[T any](dst SliceWriter[T], src *Reader[T], n int64, onSkip func(missed int64)) (int64, error) {
+	bufSize := int64(copyBufferSize)
+	if n >= 0 {
+		bufSize = min(bufSize, n)
+	}
+	buf := make([]T, bufSize)
+
+	var written int64
+	for n < 0 || written < n {
+		p := buf
+		if n >= 0 {
+			p = buf[:min(int64(len(buf)), n-written)]
+		}
+
+		c, err := src.Read(p)
+		if c > 0 {
+			wc, werr := dst.Write(p[:c])
+			written += int64(wc)
+			if werr != nil {
+				return written, werr
+			}
+			if wc != c {
+				return written, io.ErrShortWrite
+			}
+		}
+
+		switch err {
+		case nil:
+		case io.EOF:
+			return written, nil
+		case ErrStaleReader:
+			if onSkip == nil {
+				return written, err
+			}
+			onSkip(src.skipStale())
+		default:
+			return written, err
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,53 @@
+package ringslice
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	w, err := New[byte](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	w.Write([]byte("hello"))
+
+	out := &bytes.Buffer{}
+	n, err := Copy(out, r, nil)
+	if n != 5 || err != nil || out.String() != "hello" {
+		t.Errorf("failed copy test, expected hello, got %q n=%d err=%v", out.String(), n, err)
+	}
+
+	// reader becomes stale
+	w.Write([]byte("0123456789"))
+
+	var missed int64
+	out.Reset()
+	n, err = Copy(out, r, func(m int64) { missed += m })
+	if n != 8 || err != nil || missed != 2 || out.String() != "23456789" {
+		t.Errorf("failed stale copy test, got %q n=%d missed=%d err=%v", out.String(), n, missed, err)
+	}
+
+	// copy between two rings
+	dst, _ := New[byte](8)
+	dr := dst.Reader()
+	r = w.Reader()
+
+	n, err = CopyN[byte](dst, r, 3, nil)
+	if n != 3 || err != nil {
+		t.Errorf("failed CopyN test, got n=%d err=%v", n, err)
+	}
+	n, err = CopyN[byte](dst, r, 10, nil)
+	if n != 5 || err == nil {
+		t.Errorf("failed short CopyN test, got n=%d err=%v", n, err)
+	}
+
+	rbuf := make([]byte, 16)
+	c, _ := dr.Read(rbuf)
+	if string(rbuf[:c]) != "23456789" {
+		t.Errorf("failed CopyN test, expected 23456789, got %q", rbuf[:c])
+	}
+}
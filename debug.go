@@ -0,0 +1,82 @@
+package ringslice
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// DebugString returns a description of the internal state of the buffer and
+// its readers, see Dump.
+func (w *Writer[T]) DebugString() string {
+	buf := &strings.Builder{}
+	w.Dump(buf, 0, nil)
+	return buf.String()
+}
+
+// Dump writes a human readable description of the internal state of the
+// buffer to out: positions, write offset and cycle, and the position and
+// flags of each open reader. If preview is positive, up to preview of the
+// most recent elements are included, formatted with format or fmt.Sprint if
+// format is nil. The buffer is locked for reading while its state is
+// collected, so format must not call methods of the buffer. The description
+// is written to out once the lock is released, so a slow out does not delay
+// users of the buffer.
+func (w *Writer[T]) Dump(out io.Writer, preview int, format func(T) string) error {
+	if format == nil {
+		format = func(v T) string { return fmt.Sprint(v) }
+	}
+
+	var buf bytes.Buffer
+	w.mutex.RLock()
+	w.dump(&buf, preview, format)
+	w.mutex.RUnlock()
+
+	_, err := buf.WriteTo(out)
+	return err
+}
+
+// dump writes the description of the buffer written by Dump. The caller must
+// hold the lock.
+func (w *Writer[T]) dump(bw *bytes.Buffer, preview int, format func(T) string) {
+	head := w.head.Load()
+	oldest := w.oldest(head)
+	fmt.Fprintf(bw, "%T size=%d head=%d tail=%d wpos=%d cycle=%d len=%d closed=%v pinned=%d\n",
+		w, w.size, head, w.tail.Load(), head%w.size, head/w.size, head-oldest, w.closed, len(w.pins))
+
+	readers := make([]*Reader[T], 0, len(w.active))
+	for r := range w.active {
+		readers = append(readers, r)
+	}
+	slices.SortFunc(readers, func(a, b *Reader[T]) int { return cmp.Compare(a.id, b.id) })
+	for _, r := range readers {
+		pos := r.pos.Load()
+		fmt.Fprintf(bw, "  reader #%d", r.id)
+		if r.name != "" {
+			fmt.Fprintf(bw, " %q", r.name)
+		}
+		fmt.Fprintf(bw, ": pos=%d lag=%d block=%v autoskip=%v pinned=%v", pos, head-pos, r.block, r.autoSkip.Load(), r.pinned)
+		switch {
+		case pos > head:
+			bw.WriteString(" IN FUTURE")
+		case pos < oldest:
+			bw.WriteString(" STALE")
+		}
+		bw.WriteByte('\n')
+	}
+
+	if n := min(int64(max(preview, 0)), head-oldest); n > 0 {
+		fmt.Fprintf(bw, "  data[%d:%d]:", head-n, head)
+		w.data.segments(head-n, n, func(seg []T) error {
+			for _, v := range seg {
+				bw.WriteByte(' ')
+				bw.WriteString(format(v))
+			}
+			return nil
+		})
+		bw.WriteByte('\n')
+	}
+}
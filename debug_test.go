@@ -0,0 +1,55 @@
+package ringslice
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.BlockingReader()
+	defer r.Close()
+	r.SetName("main")
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	s := w.DebugString()
+	for _, part := range []string{"size=4 head=6", "wpos=2 cycle=1", `reader #1 "main": pos=0 lag=6 block=true`, "STALE"} {
+		if !strings.Contains(s, part) {
+			t.Errorf("failed debug string test, expected %q in %q", part, s)
+		}
+	}
+
+	buf := &strings.Builder{}
+	w.Dump(buf, 3, func(v int) string { return "<" + strconv.Itoa(v) + ">" })
+	if !strings.Contains(buf.String(), "data[3:6]: <4> <5> <6>\n") {
+		t.Errorf("failed dump preview test, got %q", buf.String())
+	}
+}
+
+// appendWriter appends to a buffer whenever it is written to
+type appendWriter struct{ w *Writer[int] }
+
+func (a appendWriter) Write(p []byte) (int, error) {
+	a.w.Append(len(p))
+	return len(p), nil
+}
+
+func TestDumpUnlocked(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2)
+
+	// out is written once the buffer is unlocked, so it can use the buffer
+	if err := w.Dump(appendWriter{w}, 2, nil); err != nil || w.TotalWritten() != 3 {
+		t.Errorf("failed unlocked dump test, expected 3 written, got %d err=%v", w.TotalWritten(), err)
+	}
+}
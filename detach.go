@@ -0,0 +1,32 @@
+package ringslice
+
+import "io"
+
+// Detach closes the reader and returns a copy of the data it had not read
+// yet, which can then be processed without the risk of the reader going
+// stale. If the reader already missed data and does not skip automatically
+// (see SetAutoSkip), Detach returns ErrStaleReader and the reader is left
+// open.
+func (r *Reader[T]) Detach() ([]T, error) {
+	if r.isClosed() {
+		return nil, io.ErrClosedPipe
+	}
+
+	w := r.w
+	w.mutex.RLock()
+	head := w.head.Load()
+	if r.bounded {
+		head = min(head, r.until)
+	}
+	res := make([]T, int64(len(r.ahead))+max(head-r.pos.Load(), 0))
+	n := copy(res, r.ahead)
+	m, err := r.fetchLocked(res[n:], true)
+	w.mutex.RUnlock()
+
+	if err == ErrStaleReader {
+		return nil, err
+	}
+	r.ahead = nil
+	r.Close()
+	return res[:n+m], nil
+}
@@ -0,0 +1,63 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestDetach(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	w.Append(1, 2, 3, 4)
+	if v, err := r.ReadOne(); v != 1 || err != nil {
+		t.Errorf("failed detach test, expected 1, got %d err=%v", v, err)
+	}
+	w.Append(5)
+
+	res, err := r.Detach()
+	if err != nil || !slices.Equal(res, []int{2, 3, 4, 5}) {
+		t.Errorf("failed detach test, expected [2 3 4 5], got %v err=%v", res, err)
+	}
+	if !r.Closed() || w.Stats().Readers != 0 {
+		t.Errorf("failed detach test, reader should be closed")
+	}
+	if _, err := r.Detach(); err != io.ErrClosedPipe {
+		t.Errorf("failed detach test, expected io.ErrClosedPipe, got %v", err)
+	}
+
+	// nothing left to read
+	r = w.BlockingCurrentReader()
+	if res, err := r.Detach(); err != nil || len(res) != 0 {
+		t.Errorf("failed empty detach test, expected nothing, got %v err=%v", res, err)
+	}
+}
+
+func TestDetachStale(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3, 4, 5, 6)
+	if _, err := r.Detach(); err != ErrStaleReader {
+		t.Errorf("failed stale detach test, expected ErrStaleReader, got %v", err)
+	}
+	if r.Closed() {
+		t.Errorf("failed stale detach test, reader should be open")
+	}
+
+	r.SetAutoSkip(true)
+	res, err := r.Detach()
+	if err != nil || !slices.Equal(res, []int{3, 4, 5, 6}) {
+		t.Errorf("failed auto skip detach test, expected [3 4 5 6], got %v err=%v", res, err)
+	}
+}
@@ -0,0 +1,9 @@
+// Package ringslice provides a generic ring buffer that a single Writer can
+// append to and any number of Readers can consume at their own pace. Readers
+// that do not keep up with the writer will fall out of sync and get
+// ErrStaleReader, unless auto skip is enabled.
+//
+// Once a reader has performed its first ReadOne, Write, Append, Read and
+// ReadOne do not allocate memory. This is verified by the package's tests and
+// benchmarks, and must remain true for any change to these paths.
+package ringslice
@@ -0,0 +1,100 @@
+package ringslice
+
+import "io"
+
+// ReadEach calls fn for each element available to the reader in order, while
+// locking the buffer once, until fn returns false or all available elements
+// were passed to fn. An element is considered read once passed to fn, even if
+// fn returned false. It blocks like Read until at least one element is
+// available if the reader is blocking, and returns the number of elements
+// passed to fn.
+//
+// Since the buffer is locked while fn runs, fn must not write to the buffer
+// and should return quickly.
+func (r *Reader[T]) ReadEach(fn func(T) bool) (int, error) {
+	if r.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+	if len(r.ahead) > 0 || r.limit != nil || r.w.frame > 1 {
+		// rate limits, frames and read-ahead are handled by ReadOne
+		return r.readEachSlow(fn)
+	}
+
+	w := r.w
+	w.mutex.RLock()
+	n, err := r.eachLocked(fn)
+	w.mutex.RUnlock()
+
+	if n == 0 {
+		return 0, err
+	}
+	w.rsizes.add(n)
+	if h := w.instrument(); h != nil {
+		h.OnRead(r.id, n)
+	}
+	return n, nil
+}
+
+// eachLocked implements ReadEach. The caller must hold the read lock.
+func (r *Reader[T]) eachLocked(fn func(T) bool) (int, error) {
+	w := r.w
+	for {
+		pos, head, err := r.next(false)
+		if err != nil {
+			return 0, err
+		}
+
+		now := w.clk().Now().UnixNano()
+		n := 0
+		w.data.segments(pos, head-pos, func(seg []T) error {
+			for _, v := range seg {
+				pos++
+				if r.filter != nil && !r.filter(v) {
+					continue
+				}
+				if w.expired(pos-1, now) {
+					continue
+				}
+				n++
+				if !fn(v) {
+					return errStop
+				}
+			}
+			return nil
+		})
+		r.pos.Store(pos)
+		r.release()
+		if n == 0 {
+			// everything was filtered out, try again
+			continue
+		}
+		w.verify()
+		return n, nil
+	}
+}
+
+// readEachSlow implements ReadEach with the read-ahead window, which keeps
+// the elements fn was not called for.
+func (r *Reader[T]) readEachSlow(fn func(T) bool) (int, error) {
+	n := 0
+	for {
+		if len(r.ahead) == 0 {
+			if n > 0 && r.Lag() == 0 {
+				// do not block once some data was read
+				return n, nil
+			}
+			if err := r.fill(); err != nil {
+				if n > 0 {
+					return n, nil
+				}
+				return 0, err
+			}
+		}
+		v := r.ahead[0]
+		r.ahead = r.ahead[1:]
+		n++
+		if !fn(v) {
+			return n, nil
+		}
+	}
+}
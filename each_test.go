@@ -0,0 +1,92 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestReadEach(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3, 4, 5)
+
+	var got []int
+	n, err := r.ReadEach(func(v int) bool {
+		got = append(got, v)
+		return v < 3
+	})
+	if n != 3 || err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("failed ReadEach test, expected [1 2 3], got %v n=%d err=%v", got, n, err)
+	}
+
+	got = nil
+	n, err = r.ReadEach(func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	if n != 2 || err != nil || !slices.Equal(got, []int{4, 5}) {
+		t.Errorf("failed ReadEach test, expected [4 5], got %v n=%d err=%v", got, n, err)
+	}
+
+	if _, err := r.ReadEach(func(int) bool { return true }); err != io.EOF {
+		t.Errorf("failed ReadEach eof test, expected io.EOF, got %v", err)
+	}
+}
+
+func TestReadEachAhead(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3, 4)
+	if v, err := r.ReadOne(); v != 1 || err != nil {
+		t.Errorf("failed ReadOne test, expected 1, got %d err=%v", v, err)
+	}
+
+	// remaining elements come from the read-ahead window
+	var got []int
+	r.ReadEach(func(v int) bool {
+		got = append(got, v)
+		return v < 2
+	})
+	w.Append(5)
+	r.ReadEach(func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	if !slices.Equal(got, []int{2, 3, 4, 5}) {
+		t.Errorf("failed ReadEach read-ahead test, expected [2 3 4 5], got %v", got)
+	}
+}
+
+func TestReadEachFiltered(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Subscribe(func(v int) bool { return v%2 == 0 })
+	defer r.Close()
+	w.Append(1, 2, 3, 4, 5)
+
+	var got []int
+	n, err := r.ReadEach(func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	if n != 2 || err != nil || !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("failed filtered ReadEach test, expected [2 4], got %v n=%d err=%v", got, n, err)
+	}
+}
@@ -0,0 +1,49 @@
+package ringslice
+
+import "io"
+
+// Compressor wraps a destination writer with a compression stream, such as
+// gzip. Closing the returned writer must flush all data, but not close dst.
+type Compressor func(dst io.Writer) (io.WriteCloser, error)
+
+// EncodeBytes writes p as is, and can be used as encoder for WriteSnapshot on
+// byte buffers.
+func EncodeBytes(dst io.Writer, p []byte) error {
+	_, err := dst.Write(p)
+	return err
+}
+
+// WriteSnapshot writes all the elements currently retained in the buffer,
+// from oldest to newest, to dst. Elements are passed to enc directly from the
+// buffer's storage, one contiguous segment at a time, without making an
+// intermediate copy. If compress is not nil, the output is written through
+// it.
+//
+// The buffer is locked for reading while the snapshot is written, which
+// means writes will block until WriteSnapshot returns.
+func (w *Writer[T]) WriteSnapshot(dst io.Writer, enc func(io.Writer, []T) error, compress Compressor) error {
+	out := dst
+	var cw io.WriteCloser
+	if compress != nil {
+		var err error
+		if cw, err = compress(dst); err != nil {
+			return err
+		}
+		out = cw
+	}
+
+	w.mutex.RLock()
+	head := w.head.Load()
+	oldest := w.oldest(head)
+	err := w.data.segments(oldest, head-oldest, func(seg []T) error {
+		return enc(out, seg)
+	})
+	w.mutex.RUnlock()
+
+	if cw != nil {
+		if cerr := cw.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package ringslice
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestWriteSnapshot(t *testing.T) {
+	w, err := NewChunked[byte](8, 3)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Write([]byte("hello world"))
+
+	out := &bytes.Buffer{}
+	gz := func(dst io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(dst), nil }
+	if err := w.WriteSnapshot(out, EncodeBytes, gz); err != nil {
+		t.Errorf("failed WriteSnapshot test, got err=%v", err)
+	}
+
+	zr, err := gzip.NewReader(out)
+	if err != nil {
+		t.Errorf("failed WriteSnapshot test, got err=%v", err)
+		return
+	}
+	res, _ := io.ReadAll(zr)
+	if string(res) != "lo world" {
+		t.Errorf("failed WriteSnapshot test, expected lo world, got %q", res)
+	}
+}
@@ -0,0 +1,37 @@
+package ringslice
+
+import "expvar"
+
+// expvarState is the value published by PublishExpvar
+type expvarState struct {
+	Size         int64            `json:"size"`
+	Len          int64            `json:"len"`
+	TotalWritten int64            `json:"total_written"`
+	Writes       int64            `json:"writes"`
+	Overwritten  int64            `json:"overwritten"`
+	ReaderLags   map[string]int64 `json:"reader_lags"`
+}
+
+// PublishExpvar publishes the buffer's metrics as an expvar variable with
+// the given name, so they appear in /debug/vars. The value includes the size
+// of the buffer, the number of elements retained and written, the number of
+// elements overwritten before being read (see Stats) and the lag of each
+// reader, keyed by name or ID. Like expvar.Publish, it panics if the name is
+// already in use.
+func (w *Writer[T]) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		st := w.Stats()
+		res := expvarState{
+			Size:         w.size,
+			Len:          w.Len(),
+			TotalWritten: st.Elements,
+			Writes:       st.Writes,
+			Overwritten:  st.Overwritten,
+			ReaderLags:   make(map[string]int64),
+		}
+		for _, r := range w.Readers() {
+			res.ReaderLags[r.Label()] = r.Lag
+		}
+		return res
+	}))
+}
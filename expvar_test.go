@@ -0,0 +1,38 @@
+package ringslice
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+)
+
+func TestExpvar(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	r.SetName("consumer")
+	r2 := w.Reader()
+	defer r2.Close()
+
+	w.Append(1, 2, 3)
+	r.Read(make([]int, 2))
+
+	// expvar names cannot be reused, even across test runs
+	name := fmt.Sprintf("ringslice_test_%p", w)
+	w.PublishExpvar(name)
+
+	var st expvarState
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &st); err != nil {
+		t.Errorf("failed expvar test, got err=%v", err)
+		return
+	}
+	if st.Size != 4 || st.TotalWritten != 3 || st.ReaderLags["consumer"] != 1 || st.ReaderLags["2"] != 3 {
+		t.Errorf("failed expvar test, got %+v", st)
+	}
+}
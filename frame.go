@@ -0,0 +1,63 @@
+package ringslice
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrFrameSize = errors.New("write size is not a multiple of the frame size")
+
+// NewFramed returns a new Writer whose writes and reads always transfer a
+// whole number of frames of frameSize elements, such as interleaved audio
+// samples, so frames are never split between two reads. Writes of a size
+// which is not a multiple of frameSize fail with ErrFrameSize, and reads
+// into a buffer smaller than a frame fail with io.ErrShortBuffer. size must
+// be a multiple of frameSize.
+//
+// Frames are only kept whole by Read and ReadOne; filtered readers (see
+// Subscribe), expiring elements (see WriteTTL) and collapsing (see
+// SetCollapse) work on individual elements. A reader rate limit (see
+// SetRateLimit) must allow a burst of at least one frame.
+func NewFramed[T any](size, frameSize int64) (*Writer[T], error) {
+	if frameSize == 0 {
+		return nil, errors.New("Frame size must be positive")
+	}
+	return New[T](size, WithFrameSize(frameSize))
+}
+
+// FrameSize returns the frame size of the buffer, which is 1 unless it was
+// created with NewFramed.
+func (w *Writer[T]) FrameSize() int64 {
+	return int64(w.unit())
+}
+
+// unit returns the number of elements writes and reads are a multiple of.
+func (w *Writer[T]) unit() int {
+	return int(max(w.frame, 1))
+}
+
+// floor rounds n down to a whole number of frames.
+func (w *Writer[T]) floor(n int64) int64 {
+	if w.frame > 1 {
+		return n - n%w.frame
+	}
+	return n
+}
+
+// ceil rounds n up to a whole number of frames.
+func (w *Writer[T]) ceil(n int64) int64 {
+	if w.frame > 1 {
+		return w.floor(n + w.frame - 1)
+	}
+	return n
+}
+
+// frameBuf returns p truncated to a whole number of frames, or an error if p
+// cannot hold a single frame.
+func (r *Reader[T]) frameBuf(p []T) ([]T, error) {
+	n := r.w.floor(int64(len(p)))
+	if n == 0 && len(p) > 0 {
+		return nil, io.ErrShortBuffer
+	}
+	return p[:n], nil
+}
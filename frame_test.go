@@ -0,0 +1,45 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestFramed(t *testing.T) {
+	if _, err := NewFramed[int16](10, 4); err == nil {
+		t.Errorf("failed framed size test, expected error")
+	}
+
+	w, err := NewFramed[int16](8, 2)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	if w.FrameSize() != 2 {
+		t.Errorf("failed frame size test, expected 2, got %d", w.FrameSize())
+	}
+
+	r := w.Reader()
+	defer r.Close()
+
+	if _, err := w.Append(1, 2, 3); err != ErrFrameSize {
+		t.Errorf("failed framed write test, expected ErrFrameSize, got %v", err)
+	}
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	buf := make([]int16, 3)
+	if _, err := r.Read(buf[:1]); err != io.ErrShortBuffer {
+		t.Errorf("failed short buffer test, expected io.ErrShortBuffer, got %v", err)
+	}
+	n, err := r.Read(buf)
+	if n != 2 || err != nil || !slices.Equal(buf[:n], []int16{1, 2}) {
+		t.Errorf("failed framed read test, expected [1 2], got %v err=%v", buf[:n], err)
+	}
+
+	w.Truncate(3)
+	n, _ = r.Read(buf)
+	if !slices.Equal(buf[:n], []int16{5, 6}) {
+		t.Errorf("failed framed truncate test, expected [5 6], got %v", buf[:n])
+	}
+}
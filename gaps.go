@@ -0,0 +1,32 @@
+package ringslice
+
+// maxGaps is the number of missed ranges a reader remembers
+const maxGaps = 1024
+
+// Gaps returns the ranges of positions [from, to) of the elements this reader
+// missed since the previous call, because they were overwritten before being
+// read and the reader skipped them, either automatically (see SetAutoSkip)
+// or by calling Reset. Only the last 1024 ranges are kept.
+func (r *Reader[T]) Gaps() [][2]int64 {
+	r.gapLock.Lock()
+	defer r.gapLock.Unlock()
+
+	res := r.gaps
+	r.gaps = nil
+	return res
+}
+
+// addGap records that the elements in [from, to) were missed.
+func (r *Reader[T]) addGap(from, to int64) {
+	r.gapLock.Lock()
+	defer r.gapLock.Unlock()
+
+	if n := len(r.gaps); n > 0 && r.gaps[n-1][1] == from {
+		r.gaps[n-1][1] = to
+		return
+	}
+	if len(r.gaps) == maxGaps {
+		r.gaps = append(r.gaps[:0], r.gaps[1:]...)
+	}
+	r.gaps = append(r.gaps, [2]int64{from, to})
+}
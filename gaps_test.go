@@ -0,0 +1,91 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGaps(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	r.SetAutoSkip(true)
+	buf := make([]int, 4)
+
+	w.Append(1, 2, 3, 4, 5, 6)
+	if n, err := r.Read(buf); n != 4 || err != nil || buf[0] != 3 {
+		t.Errorf("failed auto skip test, expected [3 4 5 6], got %v err=%v", buf[:n], err)
+	}
+	w.Append(7, 8, 9, 10, 11)
+	r.Read(buf)
+
+	if gaps := r.Gaps(); !slices.Equal(gaps, [][2]int64{{0, 2}, {6, 7}}) {
+		t.Errorf("failed gaps test, expected [[0 2] [6 7]], got %v", gaps)
+	}
+	if gaps := r.Gaps(); len(gaps) != 0 {
+		t.Errorf("failed gaps test, expected no gaps, got %v", gaps)
+	}
+
+	// reset after going stale
+	r.SetAutoSkip(false)
+	w.Append(12, 13, 14, 15, 16, 17)
+	if _, err := r.Read(buf); err != ErrStaleReader {
+		t.Errorf("failed stale test, expected ErrStaleReader, got %v", err)
+	}
+	r.Reset()
+	if gaps := r.Gaps(); !slices.Equal(gaps, [][2]int64{{11, 13}}) {
+		t.Errorf("failed reset gaps test, expected [[11 13]], got %v", gaps)
+	}
+}
+
+func TestMsgGaps(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := NewByteReader(b.Reader())
+	defer r.Close()
+	r.SetAutoSkip(true)
+
+	b.WriteMsg([]byte("hello"))
+	b.WriteMsg([]byte("world"))
+	// overwrites "hello" and part of "world"
+	b.WriteMsg([]byte("overwrite!"))
+
+	if msg, err := r.ReadMsg(); string(msg) != "overwrite!" || err != nil {
+		t.Errorf("failed message skip test, expected overwrite!, got %q err=%v", msg, err)
+	}
+	if gaps := r.Gaps(); !slices.Equal(gaps, [][2]int64{{0, 12}}) {
+		t.Errorf("failed message gaps test, expected [[0 12]], got %v", gaps)
+	}
+
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	ur := w.Reader()
+	defer ur.Close()
+	ur.SetAutoSkip(true)
+
+	w.WriteUnit([]int{1, 2, 3})
+	w.WriteUnit([]int{4})
+	w.WriteUnit([]int{5, 6, 7})
+	// overwrites part of the first unit
+	w.WriteUnit([]int{8, 9, 10})
+
+	if u, err := ur.ReadUnit(); !slices.Equal(u, []int{4}) || err != nil {
+		t.Errorf("failed unit skip test, expected [4], got %v err=%v", u, err)
+	}
+	if gaps := ur.Gaps(); !slices.Equal(gaps, [][2]int64{{0, 3}}) {
+		t.Errorf("failed unit gaps test, expected [[0 3]], got %v", gaps)
+	}
+}
@@ -0,0 +1,51 @@
+package ringslice
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// gobState is the representation of a Writer used by gob
+type gobState[T any] struct {
+	Size int64
+	Head int64
+	Data []T
+}
+
+// GobEncode implements gob.GobEncoder, encoding the buffer's positions and
+// retained elements, which must be gob-serializable.
+func (w *Writer[T]) GobEncode() ([]byte, error) {
+	w.mutex.RLock()
+	st := gobState[T]{
+		Size: w.size,
+		Head: w.head.Load(),
+		Data: w.snapshot(),
+	}
+	w.mutex.RUnlock()
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(&st); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, restoring a buffer encoded by
+// GobEncode. It is meant to be called on a zero Writer, such as a field of a
+// structure being decoded, and must not be called on a Writer in use.
+func (w *Writer[T]) GobDecode(data []byte) error {
+	var st gobState[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&st); err != nil {
+		return err
+	}
+
+	count := int64(len(st.Data))
+	if err := checkState(st.Size, st.Head, count); err != nil {
+		return err
+	}
+
+	w.init(st.Size, 0)
+	w.data.copyIn(st.Head-count, st.Data)
+	w.restore(st.Head, st.Head-count)
+	return nil
+}
@@ -0,0 +1,46 @@
+package ringslice
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"testing"
+)
+
+func TestGob(t *testing.T) {
+	type checkpoint struct {
+		Name   string
+		Recent *Writer[string]
+	}
+
+	w, err := New[string](3)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append("a", "b", "c", "d")
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(&checkpoint{Name: "test", Recent: w}); err != nil {
+		t.Errorf("failed gob encode test, got err=%v", err)
+		return
+	}
+
+	var res checkpoint
+	if err := gob.NewDecoder(buf).Decode(&res); err != nil {
+		t.Errorf("failed gob decode test, got err=%v", err)
+		return
+	}
+
+	if res.Name != "test" || res.Recent.TotalWritten() != 4 {
+		t.Errorf("failed gob decode test, got name=%s written=%d", res.Name, res.Recent.TotalWritten())
+	}
+	if s := res.Recent.Snapshot(); !slices.Equal(s, []string{"b", "c", "d"}) {
+		t.Errorf("failed gob decode test, expected [b c d], got %v", s)
+	}
+
+	res.Recent.Append("e")
+	if s := res.Recent.Snapshot(); !slices.Equal(s, []string{"c", "d", "e"}) {
+		t.Errorf("failed gob decode append test, expected [c d e], got %v", s)
+	}
+}
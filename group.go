@@ -0,0 +1,180 @@
+package ringslice
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+var ErrInvalidCommit = errors.New("cannot commit an element that was not delivered")
+
+// ConsumerGroup is a named group of consumers sharing a position on a
+// buffer, providing at-least-once delivery: each element is delivered to one
+// member of the group by Next, and is only considered consumed once its
+// sequence number has been committed. Rewind redelivers elements delivered
+// but not committed, for example after a member failed.
+//
+// Uncommitted elements are never overwritten: writes block once the buffer is
+// full of them, as with a pipe.
+type ConsumerGroup[T any] struct {
+	w    *Writer[T]
+	name string
+
+	next      sync.Mutex // serializes deliveries
+	mu        sync.Mutex // serializes commits and rewinds
+	r         *Reader[T] // delivery position
+	committed *Reader[T] // committed position, pinned
+}
+
+// ConsumerGroup returns the consumer group with the given name, creating it
+// at the buffer's oldest available position if it does not exist. It returns
+// nil if the writer is closed, see NewConsumerGroup.
+func (w *Writer[T]) ConsumerGroup(name string) *ConsumerGroup[T] {
+	g, _ := w.NewConsumerGroup(name)
+	return g
+}
+
+// NewConsumerGroup is like ConsumerGroup, but returns ErrWriterClosed
+// instead of a nil group if the writer is closed.
+func (w *Writer[T]) NewConsumerGroup(name string) (*ConsumerGroup[T], error) {
+	w.mutex.Lock()
+	g, ok := w.groups[name]
+	w.mutex.Unlock()
+	if ok {
+		return g, nil
+	}
+
+	r := w.internalReader(true, false)
+	if r == nil {
+		return nil, ErrWriterClosed
+	}
+	c := w.internalReader(false, false)
+	if c == nil {
+		r.Close()
+		return nil, ErrWriterClosed
+	}
+	r.SetName(name)
+	c.SetName(name + "/committed")
+
+	w.mutex.Lock()
+	if g, ok := w.groups[name]; ok {
+		// created concurrently
+		w.mutex.Unlock()
+		r.Close()
+		c.Close()
+		return g, nil
+	}
+	c.pos.Store(r.pos.Load())
+	w.pin(c)
+
+	g = &ConsumerGroup[T]{w: w, name: name, r: r, committed: c}
+	if w.groups == nil {
+		w.groups = make(map[string]*ConsumerGroup[T])
+	}
+	w.groups[name] = g
+	w.mutex.Unlock()
+
+	return g, nil
+}
+
+// Name returns the name of the group.
+func (g *ConsumerGroup[T]) Name() string {
+	return g.name
+}
+
+// Next delivers the next element to the calling member, with its sequence
+// number, blocking until one is available. It returns io.EOF once the writer
+// is closed and all elements were delivered.
+func (g *ConsumerGroup[T]) Next() (int64, T, error) {
+	g.next.Lock()
+	defer g.next.Unlock()
+
+	if g.r.isClosed() {
+		return 0, empty[T](), io.ErrClosedPipe
+	}
+
+	// waiting for data releases the buffer's lock, letting other members
+	// commit, while Rewind takes the write lock and cannot move the
+	// position between the read and the computation of seq
+	w := g.w
+	var buf [1]T
+	w.mutex.RLock()
+	_, err := g.r.fetchLocked(buf[:], false)
+	seq := g.r.pos.Load() - 1
+	w.mutex.RUnlock()
+
+	if err != nil {
+		return 0, buf[0], err
+	}
+	w.rsizes.add(1)
+	if h := w.instrument(); h != nil {
+		h.OnRead(g.r.id, 1)
+	}
+	return seq, buf[0], nil
+}
+
+// Commit marks all elements up to and including seq as consumed, allowing
+// the buffer to overwrite them. Committing an older sequence number than the
+// current committed offset has no effect. If a commit hook is set (see
+// SetCommitHook), it is called first and can fail the commit.
+func (g *ConsumerGroup[T]) Commit(seq int64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if seq >= g.r.pos.Load() {
+		return ErrInvalidCommit
+	}
+	if seq+1 <= g.committed.pos.Load() {
+		return nil
+	}
+
+	// the commit hook may veto the commit, and is called without holding
+	// the buffer's lock as it may be slow
+	w := g.w
+	if err := w.onCommit(g.name, seq+1); err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if g.committed.isClosed() {
+		return io.ErrClosedPipe
+	}
+	g.committed.pos.Store(seq + 1)
+	w.space.Broadcast()
+	return nil
+}
+
+// Committed returns the committed offset of the group, which is the
+// sequence number of the first element not consumed yet.
+func (g *ConsumerGroup[T]) Committed() int64 {
+	return g.committed.pos.Load()
+}
+
+// Rewind moves the delivery position of the group back to its committed
+// offset, so elements delivered but not committed are delivered again.
+func (g *ConsumerGroup[T]) Rewind() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	w := g.w
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	g.r.pos.Store(g.committed.pos.Load())
+}
+
+// Close removes the group from the buffer. Members blocked in Next return
+// io.ErrClosedPipe.
+func (g *ConsumerGroup[T]) Close() error {
+	w := g.w
+	w.mutex.Lock()
+	if w.groups[g.name] == g {
+		delete(w.groups, g.name)
+	}
+	w.mutex.Unlock()
+
+	g.committed.Close()
+	return g.r.Close()
+}
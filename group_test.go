@@ -0,0 +1,97 @@
+package ringslice
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestConsumerGroup(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	g := w.ConsumerGroup("workers")
+	defer g.Close()
+	if w.ConsumerGroup("workers") != g {
+		t.Errorf("failed consumer group test, expected the same group")
+	}
+
+	w.Append(10, 11, 12, 13)
+
+	seq, v, err := g.Next()
+	if seq != 0 || v != 10 || err != nil {
+		t.Errorf("failed consumer group next test, expected 0/10, got %d/%d err=%v", seq, v, err)
+	}
+	g.Next()
+	if err := g.Commit(2); err != ErrInvalidCommit {
+		t.Errorf("failed consumer group commit test, expected ErrInvalidCommit, got %v", err)
+	}
+
+	// nothing committed yet, the buffer is full
+	w.SetWriteDeadline(time.Now().Add(10 * time.Millisecond))
+	if _, err := w.Append(14); err != os.ErrDeadlineExceeded {
+		t.Errorf("failed consumer group backpressure test, expected os.ErrDeadlineExceeded, got %v", err)
+	}
+	w.SetWriteDeadline(time.Time{})
+
+	g.Commit(0)
+	if g.Committed() != 1 {
+		t.Errorf("failed consumer group committed test, expected 1, got %d", g.Committed())
+	}
+	if _, err := w.Append(14); err != nil {
+		t.Errorf("failed consumer group append test, got err=%v", err)
+	}
+
+	// redeliver 11, which was not committed
+	g.Rewind()
+	if seq, v, _ := g.Next(); seq != 1 || v != 11 {
+		t.Errorf("failed consumer group rewind test, expected 1/11, got %d/%d", seq, v)
+	}
+}
+
+func TestConsumerGroupCommitWhileWaiting(t *testing.T) {
+	w, err := New[int](2)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	g := w.ConsumerGroup("workers")
+	defer g.Close()
+	w.Append(1, 2)
+	g.Next()
+	s2, _, _ := g.Next()
+
+	// the buffer is full of uncommitted elements: the writer waits for a
+	// commit, and a member waits for data
+	written := make(chan struct{})
+	go func() {
+		w.Append(3)
+		close(written)
+	}()
+	delivered := make(chan int)
+	go func() {
+		_, v, _ := g.Next()
+		delivered <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	committed := make(chan error)
+	go func() { committed <- g.Commit(s2) }()
+	select {
+	case err := <-committed:
+		if err != nil {
+			t.Errorf("failed commit while waiting test, expected nil, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Errorf("failed commit while waiting test, commit is blocked")
+		return
+	}
+	<-written
+	if v := <-delivered; v != 3 {
+		t.Errorf("failed commit while waiting test, expected 3, got %d", v)
+	}
+}
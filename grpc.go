@@ -0,0 +1,63 @@
+package ringslice
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// ServerStream is the sending side of a server-streaming RPC, such as the
+// stream types generated for gRPC services.
+type ServerStream[T any] interface {
+	Send(T) error
+	Context() context.Context
+}
+
+// sendWriter adapts a ServerStream to a SliceWriter.
+type sendWriter[T any] struct {
+	s ServerStream[T]
+}
+
+func (sw sendWriter[T]) Write(values []T) (int, error) {
+	for i, v := range values {
+		if err := sw.s.Send(v); err != nil {
+			return i, err
+		}
+	}
+	return len(values), nil
+}
+
+// ServeStream sends the elements read from r to s until the writer is closed,
+// which returns nil, until sending fails, or until the stream's context is
+// done, which returns the context's error. r is made blocking so ServeStream
+// waits for new elements, and its blocking flag and read deadline are
+// restored on return. Stale readers are handled like Copy does.
+func ServeStream[T any](s ServerStream[T], r *Reader[T], onSkip func(missed int64)) error {
+	ctx := s.Context()
+
+	r.w.mutex.RLock()
+	block, deadline := r.block, r.deadline
+	r.w.mutex.RUnlock()
+	r.setBlocking(true)
+
+	// interrupt a pending read once the stream is done
+	done := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		r.SetReadDeadline(time.Unix(1, 0))
+		close(done)
+	})
+	defer func() {
+		if !stop() {
+			// do not let it override the restored deadline
+			<-done
+		}
+		r.setBlocking(block)
+		r.SetReadDeadline(deadline)
+	}()
+
+	_, err := Copy[T](sendWriter[T]{s}, r, onSkip)
+	if err == os.ErrDeadlineExceeded && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
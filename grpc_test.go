@@ -0,0 +1,78 @@
+package ringslice
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testStream struct {
+	ctx  context.Context
+	mu   sync.Mutex
+	sent []int
+}
+
+func (s *testStream) Send(v int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, v)
+	return nil
+}
+
+func (s *testStream) Context() context.Context { return s.ctx }
+
+func TestServeStream(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	var missed int64
+	s := &testStream{ctx: context.Background()}
+	r := w.Reader()
+	defer r.Close()
+	w.Append(7)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Append(8)
+		w.Close()
+	}()
+	if err := ServeStream(s, r, func(n int64) { missed += n }); err != nil {
+		t.Errorf("failed serve stream test, expected nil, got %v", err)
+	}
+	if !slices.Equal(s.sent, []int{4, 5, 6, 7, 8}) || missed != 1 {
+		t.Errorf("failed serve stream test, got %v missed=%d", s.sent, missed)
+	}
+}
+
+func TestServeStreamCancel(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &testStream{ctx: ctx}
+	r := w.Reader()
+	defer r.Close()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if err := ServeStream(s, r, nil); err != context.Canceled {
+		t.Errorf("failed serve stream cancel test, expected context.Canceled, got %v", err)
+	}
+
+	// the reader is left as it was
+	if _, err := r.Read(make([]int, 1)); err != io.EOF {
+		t.Errorf("failed serve stream restore test, expected io.EOF, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package ringslice
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Instrumentation receives events from a Writer and its readers, and can be
+// used to feed metrics or tracing systems. Methods are called synchronously,
+// possibly while the buffer is locked, so they must be fast and must not call
+// methods of the buffer or its readers.
+//
+// Readers are identified by their ID, see Reader.ID.
+//
+// No OpenTelemetry implementation is provided, as the module has no external
+// dependencies: one can be written outside of it by recording these events
+// with an otel Meter.
+type Instrumentation interface {
+	// OnWrite is called after n elements were written.
+	OnWrite(n int)
+	// OnRead is called after a reader read n elements.
+	OnRead(reader uint64, n int)
+	// OnBlockStart is called when a blocking reader starts waiting for data.
+	OnBlockStart(reader uint64)
+	// OnBlockEnd is called when a reader stops waiting, after waited.
+	OnBlockEnd(reader uint64, waited time.Duration)
+	// OnDrop is called when n elements are overwritten before the slowest
+	// reader read them.
+	OnDrop(n int64)
+}
+
+// SetInstrumentation sets the instrumentation receiving the events of the
+// buffer, or disables it if i is nil.
+func (w *Writer[T]) SetInstrumentation(i Instrumentation) {
+	if i == nil {
+		w.instr.Store(nil)
+		return
+	}
+	w.instr.Store(&i)
+}
+
+// instrument returns the instrumentation of the buffer, or nil.
+func (w *Writer[T]) instrument() Instrumentation {
+	if i := w.instr.Load(); i != nil {
+		return *i
+	}
+	return nil
+}
+
+// Hooks holds optional callbacks for lifecycle events of a Writer and its
+// readers. Like Instrumentation, callbacks may be called while the buffer is
+// locked and must not call methods of the buffer or its readers. Nil
+// callbacks are ignored.
+type Hooks struct {
+	// OnWrite is called when elements at positions [start, end) have been
+	// committed to the buffer.
+	OnWrite func(start, end int64)
+	// OnWrap is called when a write wraps around the end of the buffer and
+	// starts overwriting older elements, with the new cycle number.
+	OnWrap func(cycle int64)
+	// OnReaderStale is called when a reader finds that missed elements it
+	// had not read yet were overwritten. A reader not using auto skip
+	// reports it on each read until it is reset.
+	OnReaderStale func(reader uint64, missed int64)
+	// OnReaderClose is called when a reader is closed.
+	OnReaderClose func(reader uint64)
+}
+
+// SetHooks sets the callbacks called on lifecycle events of the buffer,
+// replacing any previously set hooks.
+func (w *Writer[T]) SetHooks(h Hooks) {
+	w.hooks.Store(&h)
+}
+
+// SetLogger sets a logger receiving noteworthy events of the buffer, such as
+// readers missing data or the writer being closed while readers are still
+// open. Logging is disabled if l is nil, which is the default.
+func (w *Writer[T]) SetLogger(l *slog.Logger) {
+	w.logger.Store(l)
+}
+
+// wentStale reports that the reader missed elements.
+func (r *Reader[T]) wentStale(missed int64) {
+	if h := r.w.hooks.Load(); h != nil && h.OnReaderStale != nil {
+		h.OnReaderStale(r.id, missed)
+	}
+	if l := r.w.logger.Load(); l != nil {
+		if r.autoSkip.Load() {
+			l.Warn("ringslice: reader skipped overwritten data", "reader", r.id, "dropped", missed)
+		} else {
+			l.Warn("ringslice: reader is stale", "reader", r.id, "missed", missed)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package ringslice
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testHooks struct {
+	mu                           sync.Mutex
+	written, read, blocks, drops int64
+}
+
+func (h *testHooks) OnWrite(n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.written += int64(n)
+}
+
+func (h *testHooks) OnRead(reader uint64, n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.read += int64(n)
+}
+
+func (h *testHooks) OnBlockStart(reader uint64) {}
+
+func (h *testHooks) OnBlockEnd(reader uint64, waited time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.blocks++
+}
+
+func (h *testHooks) OnDrop(n int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drops += n
+}
+
+func TestInstrumentation(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	h := &testHooks{}
+	w.SetInstrumentation(h)
+
+	r := w.BlockingReader()
+	defer r.Close()
+	r.SetAutoSkip(true)
+
+	w.Append(1, 2, 3)
+	r.Read(make([]int, 2))
+	w.Append(4, 5, 6, 7)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Append(8)
+	}()
+	buf := make([]int, 8)
+	r.Read(buf) // 4..7, 3 was dropped
+	r.Read(buf) // blocks until 8
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.written != 8 || h.read != 7 || h.drops != 1 || h.blocks != 1 {
+		t.Errorf("failed instrumentation test, got written=%d read=%d drops=%d blocks=%d", h.written, h.read, h.drops, h.blocks)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	var writes [][2]int64
+	var wraps []int64
+	var stale, closed []uint64
+	var missed int64
+	w.SetHooks(Hooks{
+		OnWrite:       func(start, end int64) { writes = append(writes, [2]int64{start, end}) },
+		OnWrap:        func(cycle int64) { wraps = append(wraps, cycle) },
+		OnReaderStale: func(reader uint64, n int64) { stale = append(stale, reader); missed += n },
+		OnReaderClose: func(reader uint64) { closed = append(closed, reader) },
+	})
+
+	r := w.Reader()
+	r.SetAutoSkip(true)
+
+	w.Append(1, 2, 3)
+	w.Append(4)
+	w.Append(5, 6)
+	w.Append(7, 8, 9)
+
+	if len(writes) != 4 || writes[3] != [2]int64{6, 9} {
+		t.Errorf("failed write hook test, got %v", writes)
+	}
+	if len(wraps) != 2 || wraps[0] != 1 || wraps[1] != 2 {
+		t.Errorf("failed wrap hook test, expected [1 2], got %v", wraps)
+	}
+
+	r.Read(make([]int, 4))
+	if len(stale) != 1 || stale[0] != r.ID() || missed != 5 {
+		t.Errorf("failed stale hook test, expected 5 missed by %d, got %d by %v", r.ID(), missed, stale)
+	}
+
+	r.Close()
+	if len(closed) != 1 || closed[0] != r.ID() {
+		t.Errorf("failed close hook test, got %v", closed)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	w, err := New[int](2)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	w.SetLogger(slog.New(slog.NewTextHandler(buf, nil)))
+
+	r := w.Reader()
+	r.SetAutoSkip(true)
+	w.Append(1, 2, 3, 4, 5)
+	r.Read(make([]int, 2))
+	w.closeWithError(nil)
+	r.Close()
+
+	s := buf.String()
+	for _, part := range []string{`msg="ringslice: reader skipped overwritten data" reader=1 dropped=3`, `msg="ringslice: writer closed" readers=1`} {
+		if !strings.Contains(s, part) {
+			t.Errorf("failed logger test, expected %q in %q", part, s)
+		}
+	}
+}
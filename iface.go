@@ -0,0 +1,22 @@
+package ringslice
+
+// RingReader is the core set of methods of a Reader, allowing code to depend
+// on an interface and use fakes in tests.
+type RingReader[T any] interface {
+	Read(p []T) (int, error)
+	ReadOne() (T, error)
+	Close() error
+}
+
+// RingWriter is the core set of methods of a Writer, allowing code to depend
+// on an interface and use fakes in tests.
+type RingWriter[T any] interface {
+	SliceWriter[T]
+	Append(values ...T) (int, error)
+	Close() error
+}
+
+var (
+	_ RingReader[byte] = (*Reader[byte])(nil)
+	_ RingWriter[byte] = (*Writer[byte])(nil)
+)
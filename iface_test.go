@@ -0,0 +1,66 @@
+package ringslice
+
+import (
+	"io"
+	"testing"
+)
+
+// sliceReader is a fake RingReader returning values from a slice.
+type sliceReader[T any] struct {
+	values []T
+}
+
+func (s *sliceReader[T]) Read(p []T) (int, error) {
+	if len(s.values) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, s.values)
+	s.values = s.values[n:]
+	return n, nil
+}
+
+func (s *sliceReader[T]) ReadOne() (T, error) {
+	if len(s.values) == 0 {
+		return empty[T](), io.EOF
+	}
+	v := s.values[0]
+	s.values = s.values[1:]
+	return v, nil
+}
+
+func (s *sliceReader[T]) Close() error {
+	return nil
+}
+
+// sumAll is an example of code depending on RingReader.
+func sumAll(r RingReader[int]) int {
+	sum := 0
+	for {
+		v, err := r.ReadOne()
+		if err != nil {
+			return sum
+		}
+		sum += v
+	}
+}
+
+func TestInterfaces(t *testing.T) {
+	if sum := sumAll(&sliceReader[int]{values: []int{1, 2, 3}}); sum != 6 {
+		t.Errorf("failed fake reader test, expected 6, got %d", sum)
+	}
+
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	var rw RingWriter[int] = w
+	rw.Append(1, 2, 3)
+	rw.Write([]int{4})
+
+	r := w.Reader()
+	defer r.Close()
+	if sum := sumAll(r); sum != 10 {
+		t.Errorf("failed reader interface test, expected 10, got %d", sum)
+	}
+}
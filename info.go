@@ -0,0 +1,90 @@
+package ringslice
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ReaderInfo describes an open reader, see Writer.Readers.
+type ReaderInfo struct {
+	ID       uint64 // unique identifier of the reader on its writer
+	Name     string // name set with SetName, if any
+	Position int64  // absolute position of the next element to read
+	Lag      int64  // number of elements written but not read yet
+
+	Blocked time.Duration // total time spent waiting for data
+}
+
+// Label returns the reader's name, or its ID if it has no name.
+func (i ReaderInfo) Label() string {
+	if i.Name != "" {
+		return i.Name
+	}
+	return strconv.FormatUint(i.ID, 10)
+}
+
+// ID returns the identifier of the reader, unique among the readers of a
+// writer.
+func (r *Reader[T]) ID() uint64 {
+	return r.id
+}
+
+// Name returns the name of the reader set by SetName.
+func (r *Reader[T]) Name() string {
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	return r.name
+}
+
+// SetName sets a name used to identify the reader in metrics and Readers.
+func (r *Reader[T]) SetName(name string) {
+	r.w.mutex.Lock()
+	defer r.w.mutex.Unlock()
+
+	r.name = name
+}
+
+// Lag returns the number of elements written to the buffer that this reader
+// has not read yet, including any that were already overwritten.
+func (r *Reader[T]) Lag() int64 {
+	return r.w.head.Load() - r.pos.Load()
+}
+
+// Readers returns information about the open readers of the buffer, ordered
+// by ID.
+func (w *Writer[T]) Readers() []ReaderInfo {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head := w.head.Load()
+	res := make([]ReaderInfo, 0, len(w.active))
+	for r := range w.active {
+		pos := r.pos.Load()
+		res = append(res, ReaderInfo{
+			ID:       r.id,
+			Name:     r.name,
+			Position: pos,
+			Lag:      head - pos,
+			Blocked:  time.Duration(r.waited.Load()),
+		})
+	}
+	slices.SortFunc(res, func(a, b ReaderInfo) int { return cmp.Compare(a.ID, b.ID) })
+	return res
+}
+
+// SlowestReader returns the open reader with the largest lag, which is the
+// first to miss data when the buffer starts overwriting unread elements. It
+// returns false if the buffer has no open reader.
+func (w *Writer[T]) SlowestReader() (ReaderInfo, bool) {
+	var res ReaderInfo
+	found := false
+	for _, r := range w.Readers() {
+		if !found || r.Lag > res.Lag {
+			res, found = r, true
+		}
+	}
+	return res, found
+}
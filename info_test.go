@@ -0,0 +1,39 @@
+package ringslice
+
+import "testing"
+
+func TestSlowestReader(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if _, ok := w.SlowestReader(); ok {
+		t.Errorf("failed slowest reader test, expected no reader")
+	}
+
+	a := w.Reader()
+	defer a.Close()
+	a.SetName("a")
+	b := w.Reader()
+	b.SetName("b")
+
+	w.Append(1, 2, 3, 4, 5)
+	a.Read(make([]int, 1))
+	b.Read(make([]int, 3))
+
+	if info, ok := w.SlowestReader(); !ok || info.Name != "a" || info.Lag != 4 || info.ID != a.ID() {
+		t.Errorf("failed slowest reader test, expected a with lag 4, got %+v", info)
+	}
+
+	a.Read(make([]int, 4))
+	if info, _ := w.SlowestReader(); info.Name != "b" || info.Lag != 2 {
+		t.Errorf("failed slowest reader test, expected b with lag 2, got %+v", info)
+	}
+
+	b.Close()
+	if r := w.Readers(); len(r) != 1 || r[0].Label() != "a" {
+		t.Errorf("failed readers test, expected only a, got %+v", r)
+	}
+}
@@ -0,0 +1,101 @@
+package ringslice
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// defaultIngestChunk is the read size used by StartIngest if none is given
+const defaultIngestChunk = 32 * 1024
+
+// Ingest is a running goroutine copying data from a source into a Writer,
+// see Writer.StartIngest.
+type Ingest struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// StartIngest starts a goroutine continuously reading from src into the
+// buffer, chunkSize elements at a time (32k if zero), until src returns an
+// error or ctx is cancelled. For a buffer of bytes, src can be any
+// io.Reader. If src has a SetReadDeadline method (such as net.Conn or
+// os.File), it is used to interrupt a pending read on stop, and the deadline
+// is cleared once the goroutine has stopped. Otherwise the goroutine stops
+// once the pending read returns.
+func (w *Writer[T]) StartIngest(ctx context.Context, src SliceReader[T], chunkSize int) *Ingest {
+	if chunkSize <= 0 {
+		chunkSize = defaultIngestChunk
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	i := &Ingest{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	stop := func() {}
+	if d, ok := src.(interface{ SetReadDeadline(time.Time) error }); ok {
+		interrupted := make(chan struct{})
+		stopFunc := context.AfterFunc(ctx, func() {
+			d.SetReadDeadline(time.Now())
+			close(interrupted)
+		})
+		stop = func() {
+			if !stopFunc() {
+				// the deadline was set to interrupt a read, give the
+				// source back without it
+				<-interrupted
+				d.SetReadDeadline(time.Time{})
+			}
+		}
+	}
+
+	go func() {
+		defer close(i.done)
+		i.err = w.ingest(ctx, src, make([]T, chunkSize))
+		stop()
+		cancel()
+	}()
+
+	return i
+}
+
+func (w *Writer[T]) ingest(ctx context.Context, src SliceReader[T], buf []T) error {
+	for ctx.Err() == nil {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				// end of input, or interrupted by stop
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Done returns a channel closed once the ingest goroutine has stopped.
+func (i *Ingest) Done() <-chan struct{} {
+	return i.done
+}
+
+// Wait waits for the ingest goroutine to stop, and returns the error that
+// caused it to stop, if any. Reaching the end of the source or being stopped
+// is not considered an error.
+func (i *Ingest) Wait() error {
+	<-i.done
+	return i.err
+}
+
+// Stop stops the ingest goroutine and waits for it to complete.
+func (i *Ingest) Stop() error {
+	i.cancel()
+	return i.Wait()
+}
@@ -0,0 +1,66 @@
+package ringslice
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIngest(t *testing.T) {
+	b, err := NewByteRing(64)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := b.Reader()
+	i := b.StartIngest(context.Background(), strings.NewReader("hello world"), 4)
+	if err := i.Wait(); err != nil {
+		t.Errorf("failed ingest test, got err=%v", err)
+	}
+
+	rbuf := make([]byte, 32)
+	n, _ := r.Read(rbuf)
+	if string(rbuf[:n]) != "hello world" {
+		t.Errorf("failed ingest test, expected hello world, got %q", rbuf[:n])
+	}
+
+	// stopping a connection with a pending read
+	c1, c2, _ := ConnPair(64)
+	defer c2.Close()
+
+	br := NewByteReader(b.BlockingCurrentReader())
+	i = b.StartIngest(context.Background(), c1, 0)
+	c2.Write([]byte("!!!"))
+
+	if s, err := br.ReadString('!'); s != "!" || err != nil {
+		t.Errorf("failed ingest test, expected !, got %q err=%v", s, err)
+	}
+
+	if err := i.Stop(); err != nil {
+		t.Errorf("failed ingest stop test, got err=%v", err)
+	}
+
+	// the connection is usable again once the ingest stopped
+	c2.Write([]byte("?"))
+	if n, err := c1.Read(rbuf); string(rbuf[:n]) != "?" || err != nil {
+		t.Errorf("failed ingest deadline test, expected ?, got %q err=%v", rbuf[:n], err)
+	}
+}
+
+func TestIngestWriter(t *testing.T) {
+	src, _ := New[int](8)
+	dst, _ := New[int](8)
+	r := src.Reader()
+	defer r.Close()
+	src.Append(1, 2, 3)
+	src.closeWithError(nil)
+
+	if err := dst.StartIngest(context.Background(), r, 2).Wait(); err != nil {
+		t.Errorf("failed writer ingest test, got err=%v", err)
+	}
+	if s := dst.Snapshot(); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("failed writer ingest test, expected [1 2 3], got %v", s)
+	}
+}
@@ -0,0 +1,92 @@
+package ringslice
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// SetStrict enables or disables strict mode, in which the internal
+// invariants of the buffer are checked after every write, read and
+// truncation. A violation panics with a description of the problem and a
+// dump of the buffer's state (see Dump). Strict mode is meant for tests and
+// debugging, as it slows down all operations.
+func (w *Writer[T]) SetStrict(enabled bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.checked.Store(w.head.Load())
+	w.strict.Store(enabled)
+}
+
+// verify checks the invariants of the buffer if strict mode is enabled, and
+// panics if one of them is violated. The caller must hold the lock.
+func (w *Writer[T]) verify() {
+	if !w.strict.Load() {
+		return
+	}
+	err := w.checkInvariants()
+	if err == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	w.dump(&buf, 16, func(v T) string { return fmt.Sprint(v) })
+	panic(fmt.Sprintf("ringslice: %v\n%s", err, buf.String()))
+}
+
+// CheckInvariants verifies the internal consistency of the buffer and its
+// readers, and returns an error describing the first problem found, if any.
+// It can be used in property based tests or health checks.
+func (w *Writer[T]) CheckInvariants() error {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.checkInvariants()
+}
+
+// checkInvariants returns an error describing the first violated invariant
+// of the buffer, if any. The caller must hold the lock.
+func (w *Writer[T]) checkInvariants() error {
+	head := w.head.Load()
+	tail := w.tail.Load()
+	switch {
+	case w.size <= 0:
+		return fmt.Errorf("invalid size %d", w.size)
+	case head < 0:
+		return fmt.Errorf("negative head %d", head)
+	case tail > head:
+		return fmt.Errorf("tail %d is past head %d", tail, head)
+	case w.pending.Load() < head:
+		return fmt.Errorf("pending write position %d is before head %d", w.pending.Load(), head)
+	case w.frame > 1 && head%w.frame != 0:
+		return fmt.Errorf("head %d is not aligned on frame size %d", head, w.frame)
+	case w.framer != nil && (w.msgTail > head || w.msgTail < w.oldest(head)):
+		return fmt.Errorf("message tail %d is outside of retained data [%d, %d]", w.msgTail, w.oldest(head), head)
+	}
+
+	if w.strict.Load() {
+		// head only moves forward, with Reopen and replication keeping
+		// positions increasing too
+		if prev := w.checked.Swap(head); head < prev {
+			return fmt.Errorf("head moved back from %d to %d", prev, head)
+		}
+	}
+
+	for r := range w.active {
+		pos := r.pos.Load()
+		switch {
+		case pos < 0:
+			return fmt.Errorf("reader #%d has negative position %d", r.id, pos)
+		case pos > head:
+			return fmt.Errorf("reader #%d at %d is ahead of the writer at %d", r.id, pos, head)
+		case r.pinned && pos < head-w.size:
+			return fmt.Errorf("pinned reader #%d at %d was overwritten, oldest is %d", r.id, pos, head-w.size)
+		}
+	}
+	for r := range w.pins {
+		if _, ok := w.active[r]; !ok {
+			return fmt.Errorf("pinned reader #%d is not open", r.id)
+		}
+	}
+	return nil
+}
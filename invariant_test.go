@@ -0,0 +1,92 @@
+package ringslice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	w, err := New[int](4, WithStrict())
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3, 4, 5)
+	r.SetAutoSkip(true)
+	buf := make([]int, 2)
+	r.Read(buf)
+	w.Truncate(1)
+
+	// simulate a corrupted reader
+	r.pos.Store(10)
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "reader #1 at 10 is ahead of the writer at 6") || !strings.Contains(msg, "IN FUTURE") {
+			t.Errorf("failed strict mode test, got panic %q", msg)
+		}
+	}()
+	w.Append(6)
+	t.Errorf("failed strict mode test, expected panic")
+}
+
+func TestCheckInvariants(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	r := w.Reader()
+	defer r.Close()
+
+	w.Append(1, 2, 3)
+	if err := w.CheckInvariants(); err != nil {
+		t.Errorf("failed invariants test, expected nil, got %v", err)
+	}
+
+	r.pos.Store(-1)
+	if err := w.CheckInvariants(); err == nil || err.Error() != "reader #1 has negative position -1" {
+		t.Errorf("failed invariants test, got %v", err)
+	}
+}
+
+func FuzzInvariants(f *testing.F) {
+	f.Add([]byte{3, 0x10, 0x81, 0x22, 0x93, 0x44})
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		w, err := NewChunked[byte](16, 4)
+		if err != nil {
+			t.Errorf("failed to initialize buffer")
+			return
+		}
+		readers := []*Reader[byte]{w.Reader(), w.Reader()}
+		readers[1].SetAutoSkip(true)
+		defer func() {
+			for _, r := range readers {
+				r.Close()
+			}
+		}()
+
+		buf := make([]byte, 8)
+		for _, op := range ops {
+			n := int(op & 0x1f)
+			switch op >> 5 {
+			case 0, 1, 2:
+				w.Write(make([]byte, n))
+			case 3, 4:
+				readers[op&1].Read(buf[:n%len(buf)])
+			case 5:
+				readers[op&1].Reset()
+			case 6:
+				w.Truncate(int64(n))
+			case 7:
+				readers[op&1].skipStale()
+			}
+			if err := w.CheckInvariants(); err != nil {
+				t.Errorf("failed invariants fuzz test after op %x: %v\n%s", op, err, w.DebugString())
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package ringslice
+
+import "encoding/json"
+
+// jsonState is the JSON representation of a Writer
+type jsonState[T any] struct {
+	Size         int64 `json:"size"`
+	TotalWritten int64 `json:"total_written"`
+	Data         []T   `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler, returning an object with the size
+// of the buffer, the total number of elements written, and the retained
+// elements ordered from oldest to newest.
+func (w *Writer[T]) MarshalJSON() ([]byte, error) {
+	w.mutex.RLock()
+	st := jsonState[T]{
+		Size:         w.size,
+		TotalWritten: w.head.Load(),
+		Data:         w.snapshot(),
+	}
+	w.mutex.RUnlock()
+
+	return json.Marshal(&st)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, restoring a buffer encoded by
+// MarshalJSON. It is meant to be called on a zero Writer and must not be
+// called on a Writer in use.
+func (w *Writer[T]) UnmarshalJSON(data []byte) error {
+	var st jsonState[T]
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+
+	count := int64(len(st.Data))
+	if st.TotalWritten == 0 {
+		// allow hand-written data without position
+		st.TotalWritten = count
+	}
+	if err := checkState(st.Size, st.TotalWritten, count); err != nil {
+		return err
+	}
+
+	w.init(st.Size, 0)
+	w.data.copyIn(st.TotalWritten-count, st.Data)
+	w.restore(st.TotalWritten, st.TotalWritten-count)
+	return nil
+}
@@ -0,0 +1,34 @@
+package ringslice
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	w, err := New[int](3)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2, 3, 4)
+
+	data, err := json.Marshal(w)
+	if err != nil || string(data) != `{"size":3,"total_written":4,"data":[2,3,4]}` {
+		t.Errorf("failed MarshalJSON test, got %s err=%v", data, err)
+	}
+
+	w2 := &Writer[int]{}
+	if err := json.Unmarshal(data, w2); err != nil {
+		t.Errorf("failed UnmarshalJSON test, got err=%v", err)
+		return
+	}
+	if s := w2.Snapshot(); w2.TotalWritten() != 4 || !slices.Equal(s, []int{2, 3, 4}) {
+		t.Errorf("failed UnmarshalJSON test, got written=%d data=%v", w2.TotalWritten(), s)
+	}
+
+	if err := json.Unmarshal([]byte(`{"size":1,"data":[1,2]}`), &Writer[int]{}); err != ErrInvalidState {
+		t.Errorf("failed invalid UnmarshalJSON test, got err=%v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package ringslice
+
+import (
+	"cmp"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// LeakError is returned by LeakCheck when readers are still open.
+type LeakError struct {
+	Readers []LeakedReader
+}
+
+// LeakedReader describes a reader that has not been closed.
+type LeakedReader struct {
+	ReaderInfo
+	Stack string // stack trace of the reader's creation, if recorded
+}
+
+func (e *LeakError) Error() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "ringslice: %d reader(s) not closed", len(e.Readers))
+	for _, r := range e.Readers {
+		fmt.Fprintf(buf, "\n\nreader #%d", r.ID)
+		if r.Name != "" {
+			fmt.Fprintf(buf, " %q", r.Name)
+		}
+		fmt.Fprintf(buf, " at position %d", r.Position)
+		if r.Stack != "" {
+			buf.WriteString(", created by:\n")
+			buf.WriteString(r.Stack)
+		}
+	}
+	return buf.String()
+}
+
+// SetLeakCheck enables or disables recording the stack trace of each reader
+// created from now on, so LeakCheck can report where unclosed readers were
+// created. This is a debugging aid with a cost on reader creation.
+func (w *Writer[T]) SetLeakCheck(enabled bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.leakCheck = enabled
+}
+
+// LeakCheck returns a *LeakError listing the readers of the buffer that have
+// not been closed, with their creation stack if SetLeakCheck was enabled when
+// they were created, or nil if all readers are closed. This is useful to find
+// which reader prevents Close from returning.
+func (w *Writer[T]) LeakCheck() error {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if len(w.active) == 0 {
+		return nil
+	}
+
+	head := w.head.Load()
+	res := &LeakError{}
+	for r := range w.active {
+		pos := r.pos.Load()
+		res.Readers = append(res.Readers, LeakedReader{
+			ReaderInfo: ReaderInfo{ID: r.id, Name: r.name, Position: pos, Lag: head - pos},
+			Stack:      string(r.stack),
+		})
+	}
+	slices.SortFunc(res.Readers, func(a, b LeakedReader) int { return cmp.Compare(a.ID, b.ID) })
+	return res
+}
+
+// stack returns the stack trace of the calling goroutine.
+func stack() []byte {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,36 @@
+package ringslice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLeakCheck(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.SetLeakCheck(true)
+
+	r := w.Reader()
+	r.SetName("forgotten")
+	r2 := w.Reader()
+	r2.Close()
+
+	err = w.LeakCheck()
+	var leak *LeakError
+	if !errors.As(err, &leak) || len(leak.Readers) != 1 || leak.Readers[0].Name != "forgotten" {
+		t.Errorf("failed leak check test, got %v", err)
+		return
+	}
+	if !strings.Contains(leak.Readers[0].Stack, "TestLeakCheck") || !strings.Contains(err.Error(), "TestLeakCheck") {
+		t.Errorf("failed leak check stack test, got %s", err)
+	}
+
+	r.Close()
+	if err := w.LeakCheck(); err != nil {
+		t.Errorf("failed leak check test, expected nil, got %v", err)
+	}
+}
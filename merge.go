@@ -0,0 +1,98 @@
+package ringslice
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// MergeReader reads from several writers as a single stream, interleaving
+// data as it becomes available on each of them.
+type MergeReader[T any] struct {
+	writers []*Writer[T]
+	readers []*Reader[T]
+	next    int  // source to try first, for fairness
+	ordered bool // always try sources in order, see PriorityRing
+
+	notify    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMergeReader returns a reader consuming all the given writers from their
+// oldest available position. Reads block until data is available on any of
+// them, and return io.EOF once all writers are closed and fully read.
+func NewMergeReader[T any](writers ...*Writer[T]) *MergeReader[T] {
+	m := &MergeReader[T]{
+		writers: writers,
+		notify:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	for _, w := range writers {
+		w.watch(m.notify)
+		if r := w.internalReader(false, false); r != nil {
+			m.readers = append(m.readers, r)
+		}
+	}
+	return m
+}
+
+// Read reads data from the first source with data available, trying sources
+// in turn. Read returns data from a single source per call.
+//
+// Readers returned by PriorityRing.Reader always try sources in priority
+// order instead.
+func (m *MergeReader[T]) Read(p []T) (int, error) {
+	for {
+		select {
+		case <-m.done:
+			return 0, io.ErrClosedPipe
+		default:
+		}
+
+		open := false
+		for i := range m.readers {
+			idx := (m.next + i) % len(m.readers)
+			r := m.readers[idx]
+			n, err := r.Read(p)
+			if n > 0 {
+				if !m.ordered {
+					m.next = idx + 1
+				}
+				return n, nil
+			}
+			// a source without data (ErrNoData from strict readers) lets
+			// the next one be tried
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, ErrNoData) {
+				return 0, err
+			}
+			if !r.w.isClosed() {
+				open = true
+			}
+		}
+		if !open {
+			return 0, io.EOF
+		}
+
+		select {
+		case <-m.notify:
+		case <-m.done:
+			return 0, io.ErrClosedPipe
+		}
+	}
+}
+
+// Close closes the readers of all sources. Blocked reads return
+// io.ErrClosedPipe.
+func (m *MergeReader[T]) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.done)
+		for _, w := range m.writers {
+			w.unwatch(m.notify)
+		}
+		for _, r := range m.readers {
+			r.Close()
+		}
+	})
+	return nil
+}
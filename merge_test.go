@@ -0,0 +1,79 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestMergeReader(t *testing.T) {
+	a, _ := New[int](8)
+	b, _ := New[int](8)
+
+	m := NewMergeReader(a, b)
+	defer m.Close()
+
+	a.Append(1, 2)
+	b.Append(10)
+
+	var res []int
+	buf := make([]int, 8)
+	for len(res) < 3 {
+		n, err := m.Read(buf)
+		if err != nil {
+			t.Errorf("failed merge reader test, got err=%v", err)
+			return
+		}
+		res = append(res, buf[:n]...)
+	}
+	slices.Sort(res)
+	if !slices.Equal(res, []int{1, 2, 10}) {
+		t.Errorf("failed merge reader test, expected [1 2 10], got %v", res)
+	}
+
+	// blocks until data arrives on any source
+	go b.Append(11)
+	if n, err := m.Read(buf); n != 1 || buf[0] != 11 || err != nil {
+		t.Errorf("failed merge reader blocking test, expected 11, got %v err=%v", buf[:n], err)
+	}
+
+	a.closeWithError(nil)
+	go b.closeWithError(nil)
+	if _, err := m.Read(buf); err != io.EOF {
+		t.Errorf("failed merge reader close test, expected io.EOF, got %v", err)
+	}
+}
+
+func TestMergeReaderOptions(t *testing.T) {
+	a, _ := New[int](2, WithBlocking(true), WithFullPolicy(Block))
+	b, _ := New[int](2, WithBlocking(true), WithFullPolicy(Block))
+
+	m := NewMergeReader(a, b)
+	defer m.Close()
+
+	// an empty first source does not block reads from the second one
+	b.Append(1)
+	buf := make([]int, 2)
+	if n, err := m.Read(buf); n != 1 || buf[0] != 1 || err != nil {
+		t.Errorf("failed merge reader blocking option test, expected 1, got %v err=%v", buf[:n], err)
+	}
+
+	// readers of the merge reader do not block writes on the sources
+	if n, err := a.Append(1, 2, 3); n != 3 || err != nil {
+		t.Errorf("failed merge reader full policy test, expected 3, got %d err=%v", n, err)
+	}
+}
+
+func TestMergeReaderStrictEOF(t *testing.T) {
+	a, _ := New[int](4, WithStrictEOF(true))
+	b, _ := New[int](4, WithStrictEOF(true))
+
+	m := NewMergeReader(a, b)
+	defer m.Close()
+
+	b.Append(1)
+	buf := make([]int, 2)
+	if n, err := m.Read(buf); n != 1 || buf[0] != 1 || err != nil {
+		t.Errorf("failed merge reader strict EOF test, expected 1, got %v err=%v", buf[:n], err)
+	}
+}
@@ -0,0 +1,183 @@
+package ringslice
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var (
+	ErrMsgTooLarge = errors.New("message does not fit in the ring buffer")
+	ErrMixedWrites = errors.New("cannot mix message or unit writes with other writes")
+
+	errBadFrame = errors.New("ringbuffer message framing is corrupted")
+)
+
+// framed returns ErrMixedWrites if the buffer holds data not written as
+// units (see WriteUnit) or messages (see WriteMsg), as selected by unit. The
+// caller must hold the lock.
+func (w *Writer[T]) framed(unit bool) error {
+	if w.framer == nil {
+		if head := w.head.Load(); w.oldest(head) < head {
+			// retained data was written without framing
+			return ErrMixedWrites
+		}
+		return nil
+	}
+	if _, ok := w.framer.(*unitFramer[T]); ok != unit {
+		return ErrMixedWrites
+	}
+	return nil
+}
+
+// framer keeps track of message boundaries in a ring so readers can always
+// resume reading at the start of a message.
+type framer interface {
+	// align moves the writer's msgTail to the first message boundary at or
+	// after pos. It is called with the writer's lock held, before data prior
+	// to pos is discarded.
+	align(pos int64)
+}
+
+// byteFramer tracks length-prefixed messages written by ByteRing.WriteMsg.
+type byteFramer struct {
+	w *Writer[byte]
+}
+
+func (f byteFramer) align(pos int64) {
+	w := f.w
+	head := w.head.Load()
+	for w.msgTail < pos && w.msgTail < head {
+		l, n, err := msgHeader(w, w.msgTail, head)
+		if err != nil {
+			w.msgTail = head
+			return
+		}
+		w.msgTail += int64(n) + int64(l)
+	}
+}
+
+// msgHeader decodes the length of the message starting at pos.
+func msgHeader(w *Writer[byte], pos, head int64) (uint64, int, error) {
+	var buf [binary.MaxVarintLen64]byte
+	c := min(int64(len(buf)), head-pos)
+	w.data.copyOut(buf[:c], pos)
+
+	l, n := binary.Uvarint(buf[:c])
+	if n <= 0 || pos+int64(n)+int64(l) > head {
+		return 0, 0, errBadFrame
+	}
+	return l, n, nil
+}
+
+// WriteMsg appends p to the buffer as a single length-prefixed message that
+// can be read back with ByteReader.ReadMsg. Messages are written atomically,
+// and readers will never receive partial messages, including when resuming
+// from the oldest retained data. A buffer used for messages must not be
+// written to by other means.
+func (b *ByteRing) WriteMsg(p []byte) error {
+	var hdr [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(hdr[:], uint64(len(p)))
+
+	w := b.Writer
+	if int64(n+len(p)) > w.size {
+		return ErrMsgTooLarge
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.framed(false); err != nil {
+		return err
+	}
+	if err := w.reserve(n + len(p)); err != nil {
+		return err
+	}
+	w.writes++
+	w.wsizes.add(len(p))
+
+	if w.framer == nil {
+		w.framer = byteFramer{w}
+		w.msgTail = w.head.Load()
+	}
+
+	// make sure the first retained message is known before overwriting
+	w.framer.align(w.head.Load() + int64(n+len(p)) - w.size)
+
+	// header and payload become visible to readers at once
+	w.writeVec([][]byte{hdr[:n], p})
+	if h := w.instrument(); h != nil {
+		h.OnWrite(n + len(p))
+	}
+
+	w.broadcast()
+	return nil
+}
+
+// ReadMsg reads the next message written by ByteRing.WriteMsg. If no message
+// is available, ReadMsg either returns io.EOF or blocks if the reader is
+// blocking. ReadMsg must not be mixed with other read methods on the same
+// reader.
+func (b *ByteReader) ReadMsg() ([]byte, error) {
+	r := b.Reader
+	if r.isClosed() {
+		return nil, io.ErrClosedPipe
+	}
+
+	w := r.w
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head, err := r.wait()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.seekMsg(head); err != nil {
+		return nil, err
+	}
+
+	pos := r.pos.Load()
+	l, n, err := msgHeader(w, pos, head)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, l)
+	w.data.copyOut(msg, pos+int64(n))
+	r.pos.Store(pos + int64(n) + int64(l))
+	r.release()
+	w.rsizes.add(len(msg))
+	if h := w.instrument(); h != nil {
+		h.OnRead(r.id, n+len(msg))
+	}
+	return msg, nil
+}
+
+// seekMsg ensures the reader is positioned at a message boundary with a
+// message available to read. The caller must hold the read lock.
+func (r *Reader[T]) seekMsg(head int64) error {
+	w := r.w
+	pos := r.pos.Load()
+	if pos < head-w.size {
+		r.wentStale(head - w.size - pos)
+		if !r.autoSkip.Load() {
+			return ErrStaleReader
+		}
+		r.addGap(pos, w.msgTail)
+		pos = w.msgTail
+	}
+	if pos < w.msgTail {
+		// resume at the first complete message
+		r.addGap(pos, w.msgTail)
+		pos = w.msgTail
+	}
+	r.pos.Store(pos)
+
+	if pos > head {
+		return errReaderInFuture
+	}
+	if pos == head {
+		return r.eof()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package ringslice
+
+import (
+	"io"
+	"testing"
+)
+
+func TestMsg(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := NewByteReader(b.Reader())
+
+	b.WriteMsg([]byte("hello"))
+	b.WriteMsg([]byte("world"))
+
+	msg, err := r.ReadMsg()
+	if string(msg) != "hello" || err != nil {
+		t.Errorf("failed ReadMsg test, expected hello, got %q err=%v", msg, err)
+	}
+
+	// wraps around and overwrites part of "world"
+	b.WriteMsg([]byte("overwrite!"))
+	b.WriteMsg([]byte(""))
+
+	_, err = r.ReadMsg()
+	if err != ErrStaleReader {
+		t.Errorf("failed stale ReadMsg test, expected ErrStaleReader, got err=%v", err)
+	}
+
+	// a new reader starts at the first complete message
+	r = NewByteReader(b.Reader())
+	msg, err = r.ReadMsg()
+	if string(msg) != "overwrite!" || err != nil {
+		t.Errorf("failed ReadMsg after wrap test, expected overwrite!, got %q err=%v", msg, err)
+	}
+
+	msg, err = r.ReadMsg()
+	if len(msg) != 0 || err != nil {
+		t.Errorf("failed empty ReadMsg test, got %q err=%v", msg, err)
+	}
+
+	_, err = r.ReadMsg()
+	if err != io.EOF {
+		t.Errorf("failed ReadMsg EOF test, expected io.EOF, got err=%v", err)
+	}
+
+	if err = b.WriteMsg(make([]byte, 16)); err != ErrMsgTooLarge {
+		t.Errorf("failed large WriteMsg test, expected ErrMsgTooLarge, got err=%v", err)
+	}
+}
+
+func TestMsgSingleWrite(t *testing.T) {
+	b, err := NewByteRing(16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	// header and payload are written at once
+	var writes [][2]int64
+	b.SetHooks(Hooks{OnWrite: func(start, end int64) { writes = append(writes, [2]int64{start, end}) }})
+
+	b.WriteMsg([]byte("hello"))
+	if len(writes) != 1 || writes[0] != [2]int64{0, 6} {
+		t.Errorf("failed message write hook test, expected [[0 6]], got %v", writes)
+	}
+}
@@ -0,0 +1,57 @@
+package ringslice
+
+import "errors"
+
+// FailurePolicy defines how a FanOut handles writers failing.
+type FailurePolicy int
+
+const (
+	// FailFast stops at the first writer returning an error, later writers
+	// do not receive the data.
+	FailFast FailurePolicy = iota
+	// BestEffort writes to all writers regardless of failures, and returns
+	// all errors joined together.
+	BestEffort
+)
+
+// FanOut writes each append to multiple writers, see MultiWriter.
+type FanOut[T any] struct {
+	writers []*Writer[T]
+	policy  FailurePolicy
+}
+
+// MultiWriter returns a FanOut duplicating writes to all the provided
+// writers, in order. The default policy is FailFast.
+func MultiWriter[T any](writers ...*Writer[T]) *FanOut[T] {
+	return &FanOut[T]{writers: writers}
+}
+
+// SetPolicy sets the policy used when one of the writers fails.
+func (f *FanOut[T]) SetPolicy(policy FailurePolicy) {
+	f.policy = policy
+}
+
+// Append values to all writers.
+func (f *FanOut[T]) Append(values ...T) (int, error) {
+	return f.Write(values)
+}
+
+// Write writes values to all writers. The returned count is the smallest
+// amount of values written to any writer.
+func (f *FanOut[T]) Write(values []T) (int, error) {
+	res := len(values)
+	var errs []error
+
+	for _, w := range f.writers {
+		n, err := w.Write(values)
+		res = min(res, n)
+		if err != nil {
+			if f.policy == FailFast {
+				return res, err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return res, errors.Join(errs...)
+}
@@ -0,0 +1,36 @@
+package ringslice
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestMultiWriter(t *testing.T) {
+	w1, _ := New[int](4)
+	w2, _ := New[int](4)
+	w3, _ := New[int](4)
+
+	m := MultiWriter(w1, w2, w3)
+	m.Append(1, 2)
+
+	for _, w := range []*Writer[int]{w1, w2, w3} {
+		if w.TotalWritten() != 2 {
+			t.Errorf("failed multi writer test, expected 2 values, got %d", w.TotalWritten())
+		}
+	}
+
+	w2.Close()
+
+	n, err := m.Append(3)
+	if n != 0 || err != io.ErrClosedPipe || w3.TotalWritten() != 2 {
+		t.Errorf("failed fail fast test, got n=%d err=%v", n, err)
+	}
+
+	m.SetPolicy(BestEffort)
+
+	n, err = m.Append(3)
+	if n != 0 || !errors.Is(err, io.ErrClosedPipe) || w3.TotalWritten() != 3 {
+		t.Errorf("failed best effort test, got n=%d err=%v", n, err)
+	}
+}
@@ -0,0 +1,92 @@
+package ringslice
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Summarize computes statistics over all the elements retained in w. The
+// statistics are computed in place while w is locked for reading, without
+// copying the elements.
+func Summarize[T Number](w *Writer[T]) Aggregate[T] {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return aggregate(w, 0)
+}
+
+// Sum returns the sum of the elements retained in w, which wraps on overflow
+// like any arithmetic on T.
+func Sum[T Number](w *Writer[T]) T {
+	return Summarize(w).Sum
+}
+
+// Min returns the smallest element retained in w, or false if w is empty.
+func Min[T Number](w *Writer[T]) (T, bool) {
+	res := Summarize(w)
+	return res.Min, res.Count > 0
+}
+
+// Max returns the largest element retained in w, or false if w is empty.
+func Max[T Number](w *Writer[T]) (T, bool) {
+	res := Summarize(w)
+	return res.Max, res.Count > 0
+}
+
+// Mean returns the average of the elements retained in w, or zero if w is
+// empty.
+func Mean[T Number](w *Writer[T]) float64 {
+	return Summarize(w).Mean
+}
+
+// Quantile returns the q-quantile (0 <= q <= 1) of the elements retained in
+// w, interpolating linearly between the closest elements. It returns NaN if
+// w is empty or q is out of range. The elements are copied and sorted, so
+// Quantiles should be used to compute several quantiles at once.
+func Quantile[T Number](w *Writer[T], q float64) float64 {
+	return Quantiles(w, q)[0]
+}
+
+// Quantiles returns the quantiles qs of the elements retained in w, as
+// computed by Quantile.
+func Quantiles[T Number](w *Writer[T], qs ...float64) []float64 {
+	w.mutex.RLock()
+	values := w.snapshot()
+	w.mutex.RUnlock()
+
+	slices.Sort(values)
+
+	res := make([]float64, len(qs))
+	for i, q := range qs {
+		if len(values) == 0 || !(q >= 0 && q <= 1) {
+			res[i] = math.NaN()
+			continue
+		}
+		pos := q * float64(len(values)-1)
+		lo := int(pos)
+		res[i] = float64(values[lo])
+		if lo+1 < len(values) {
+			res[i] += (pos - float64(lo)) * (float64(values[lo+1]) - float64(values[lo]))
+		}
+	}
+	return res
+}
+
+// Histogram counts the elements retained in w by bucket, in a single pass
+// while w is locked for reading. buckets holds the upper bounds of the
+// buckets in increasing order: the element v is counted in the first bucket
+// i with v <= buckets[i], and the returned slice has an additional last
+// bucket for elements larger than all bounds.
+func Histogram[T Number](w *Writer[T], buckets []float64) []int64 {
+	res := make([]int64, len(buckets)+1)
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	w.each(0, func(_ int64, v T) bool {
+		res[sort.SearchFloat64s(buckets, float64(v))]++
+		return true
+	})
+	return res
+}
@@ -0,0 +1,102 @@
+package ringslice
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNumeric(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if _, ok := Min(w); ok {
+		t.Errorf("failed empty min test, expected false")
+	}
+	if m := Mean(w); m != 0 {
+		t.Errorf("failed empty mean test, expected 0, got %v", m)
+	}
+
+	w.Append(100, 3, -2, 8, 1)
+
+	if s := Sum(w); s != 10 {
+		t.Errorf("failed sum test, expected 10, got %d", s)
+	}
+	if v, ok := Min(w); !ok || v != -2 {
+		t.Errorf("failed min test, expected -2, got %d", v)
+	}
+	if v, ok := Max(w); !ok || v != 8 {
+		t.Errorf("failed max test, expected 8, got %d", v)
+	}
+	if m := Mean(w); m != 2.5 {
+		t.Errorf("failed mean test, expected 2.5, got %v", m)
+	}
+	if res := Summarize(w); res.Count != 4 {
+		t.Errorf("failed summarize test, expected 4 elements, got %d", res.Count)
+	}
+}
+
+func TestMeanOverflow(t *testing.T) {
+	w, err := New[int8](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append(100, 100)
+	if m := Mean(w); m != 100 {
+		t.Errorf("failed mean overflow test, expected 100, got %v", m)
+	}
+	// Sum wraps like int8 arithmetic
+	if s := Sum(w); s != -56 {
+		t.Errorf("failed sum overflow test, expected -56, got %d", s)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	w, err := New[int](100)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if q := Quantile(w, 0.5); !math.IsNaN(q) {
+		t.Errorf("failed empty quantile test, expected NaN, got %v", q)
+	}
+
+	for i := 100; i > 0; i-- {
+		w.Append(i)
+	}
+
+	res := Quantiles(w, 0, 0.5, 0.95, 0.99, 1, 2)
+	expect := []float64{1, 50.5, 95.05, 99.01, 100}
+	for i, e := range expect {
+		if math.Abs(res[i]-e) > 1e-9 {
+			t.Errorf("failed quantile test, expected %v, got %v", e, res[i])
+		}
+	}
+	if !math.IsNaN(res[5]) {
+		t.Errorf("failed quantile range test, expected NaN, got %v", res[5])
+	}
+	if q := Quantile(w, 0.25); q != 25.75 {
+		t.Errorf("failed quantile test, expected 25.75, got %v", q)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	w, err := New[float64](6)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append(1000, 0.5, 1, 2.5, 10, 50, 3)
+
+	res := Histogram(w, []float64{1, 5, 10})
+	if !slices.Equal(res, []int64{2, 2, 1, 1}) {
+		t.Errorf("failed histogram test, expected [2 2 1 1], got %v", res)
+	}
+}
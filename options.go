@@ -0,0 +1,126 @@
+package ringslice
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var ErrBufferFull = errors.New("ringbuffer is full of data not read yet")
+
+// FullPolicy defines what happens to writes when the buffer is full of
+// elements its readers did not read yet.
+type FullPolicy int
+
+const (
+	// Overwrite overwrites the oldest elements, readers which did not read
+	// them become stale (see ErrStaleReader and SetAutoSkip).
+	Overwrite FullPolicy = iota
+	// Block makes writes wait until all readers have read enough elements.
+	Block
+	// Reject makes writes which do not fit fail with ErrBufferFull, without
+	// writing anything.
+	Reject
+)
+
+// Option configures a Writer created by New.
+type Option func(*config)
+
+// config holds the settings of a Writer collected from options.
+type config struct {
+	pageSize   int64
+	frameSize  int64
+	blocking   bool
+	autoSkip   bool
+	strictEOF  bool
+	full       FullPolicy
+	timestamps bool
+	maxAge     time.Duration
+	hooks      *Hooks
+	instr      Instrumentation
+	logger     *slog.Logger
+	clock      Clock
+	leakCheck  bool
+	strict     bool
+	marks      *Watermarks
+}
+
+// WithPageSize splits the storage of the buffer in pages, see NewChunked.
+func WithPageSize(n int64) Option {
+	return func(c *config) { c.pageSize = n }
+}
+
+// WithFrameSize makes writes and reads transfer whole frames of n elements,
+// see NewFramed.
+func WithFrameSize(n int64) Option {
+	return func(c *config) { c.frameSize = n }
+}
+
+// WithBlocking makes readers returned by Reader block until data is
+// available, like BlockingReader.
+func WithBlocking(enabled bool) Option {
+	return func(c *config) { c.blocking = enabled }
+}
+
+// WithAutoSkip enables auto skip on all new readers, see SetAutoSkip.
+func WithAutoSkip(enabled bool) Option {
+	return func(c *config) { c.autoSkip = enabled }
+}
+
+// WithStrictEOF enables strict EOF on all new readers, see SetStrictEOF.
+func WithStrictEOF(enabled bool) Option {
+	return func(c *config) { c.strictEOF = enabled }
+}
+
+// WithFullPolicy sets what happens to writes when readers fall behind. With
+// a policy other than Overwrite, all readers hold their position like a
+// WorkQueue does, so a reader which is not closed blocks or fails writes
+// forever.
+func WithFullPolicy(p FullPolicy) Option {
+	return func(c *config) { c.full = p }
+}
+
+// WithTimestamps enables timestamps, see SetTimestamps.
+func WithTimestamps() Option {
+	return func(c *config) { c.timestamps = true }
+}
+
+// WithMaxAge sets the maximum age of elements, see SetMaxAge.
+func WithMaxAge(d time.Duration) Option {
+	return func(c *config) { c.maxAge = d }
+}
+
+// WithWatermarks sets depth watermarks, see SetWatermarks.
+func WithWatermarks(m Watermarks) Option {
+	return func(c *config) { c.marks = &m }
+}
+
+// WithHooks sets event hooks, see SetHooks.
+func WithHooks(h Hooks) Option {
+	return func(c *config) { c.hooks = &h }
+}
+
+// WithInstrumentation sets the instrumentation, see SetInstrumentation.
+func WithInstrumentation(i Instrumentation) Option {
+	return func(c *config) { c.instr = i }
+}
+
+// WithLogger sets the logger, see SetLogger.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *config) { c.logger = l }
+}
+
+// WithClock sets the clock, see SetClock.
+func WithClock(clock Clock) Option {
+	return func(c *config) { c.clock = clock }
+}
+
+// WithStrict enables strict mode, see SetStrict.
+func WithStrict() Option {
+	return func(c *config) { c.strict = true }
+}
+
+// WithLeakCheck enables leak checking, see SetLeakCheck.
+func WithLeakCheck() Option {
+	return func(c *config) { c.leakCheck = true }
+}
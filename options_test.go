@@ -0,0 +1,100 @@
+package ringslice
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	clock := NewManualClock(time.Unix(1000, 0))
+	var stale int64
+	w, err := New[int](4,
+		WithAutoSkip(true),
+		WithClock(clock),
+		WithMaxAge(time.Minute),
+		WithHooks(Hooks{OnReaderStale: func(_ uint64, missed int64) { stale += missed }}),
+	)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	buf := make([]int, 4)
+	n, err := r.Read(buf)
+	if n != 4 || err != nil || buf[0] != 3 || stale != 2 {
+		t.Errorf("failed auto skip option test, got %v err=%v stale=%d", buf[:n], err, stale)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if l := len(w.Snapshot()); l != 0 {
+		t.Errorf("failed max age option test, expected no elements, got %d", l)
+	}
+
+	if _, err := New[int](10, WithFrameSize(4)); err == nil {
+		t.Errorf("failed frame size option test, expected error")
+	}
+}
+
+func TestBlockingOption(t *testing.T) {
+	w, err := New[int](4, WithBlocking(true))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Append(1)
+		w.closeWithError(nil)
+	}()
+
+	if v, err := r.ReadOne(); v != 1 || err != nil {
+		t.Errorf("failed blocking option test, expected 1, got %d err=%v", v, err)
+	}
+	if _, err := r.ReadOne(); err != io.EOF {
+		t.Errorf("failed blocking option test, expected io.EOF, got %v", err)
+	}
+}
+
+func TestFullPolicy(t *testing.T) {
+	w, err := New[int](4, WithFullPolicy(Reject))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+
+	w.Append(1, 2, 3)
+	if n, err := w.Append(4, 5); n != 0 || err != ErrBufferFull {
+		t.Errorf("failed reject test, expected ErrBufferFull, got %d err=%v", n, err)
+	}
+	r.ReadOne()
+	if n, err := w.Append(4, 5); n != 2 || err != nil {
+		t.Errorf("failed reject test, expected 2, got %d err=%v", n, err)
+	}
+
+	w, _ = New[int](2, WithFullPolicy(Block))
+	r = w.Reader()
+	defer r.Close()
+
+	done := make(chan struct{})
+	go func() {
+		w.Append(1, 2, 3)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if n := w.TotalWritten(); n != 2 {
+		t.Errorf("failed block test, expected 2 written, got %d", n)
+	}
+	r.ReadOne()
+	<-done
+}
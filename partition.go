@@ -0,0 +1,106 @@
+package ringslice
+
+import (
+	"errors"
+	"sync"
+)
+
+// Partitioned routes elements to a fixed number of buffers according to a key
+// computed for each element, so elements sharing a key are kept in order in
+// the same partition while partitions can be consumed in parallel.
+type Partitioned[T any] struct {
+	parts []*Writer[T]
+	key   func(T) uint64
+}
+
+// NewPartitioned returns n partitions of the given size each, routing each
+// element to partition key(v) % n. The key function would typically hash an
+// identifier of the element, for example with hash/maphash.
+func NewPartitioned[T any](n int, size int64, key func(T) uint64) (*Partitioned[T], error) {
+	if n <= 0 {
+		return nil, errors.New("Partition count must be positive")
+	}
+
+	p := &Partitioned[T]{parts: make([]*Writer[T], n), key: key}
+	for i := range p.parts {
+		w, err := New[T](size)
+		if err != nil {
+			return nil, err
+		}
+		p.parts[i] = w
+	}
+	return p, nil
+}
+
+// Append writes values to their partitions.
+func (p *Partitioned[T]) Append(values ...T) (int, error) {
+	return p.Write(values)
+}
+
+// Write writes values to their partitions. Consecutive values routed to the
+// same partition are written at once. On error, Write returns the number of
+// values written before the failing write.
+func (p *Partitioned[T]) Write(values []T) (int, error) {
+	n := 0
+	for n < len(values) {
+		part := p.PartitionOf(values[n])
+		end := n + 1
+		for end < len(values) && p.PartitionOf(values[end]) == part {
+			end++
+		}
+
+		c, err := p.parts[part].Write(values[n:end])
+		n += c
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// PartitionOf returns the index of the partition v is routed to.
+func (p *Partitioned[T]) PartitionOf(v T) int {
+	return int(p.key(v) % uint64(len(p.parts)))
+}
+
+// Partitions returns the number of partitions.
+func (p *Partitioned[T]) Partitions() int {
+	return len(p.parts)
+}
+
+// Partition returns the buffer of partition i, which can be used to create
+// readers or inspect the partition.
+func (p *Partitioned[T]) Partition(i int) *Writer[T] {
+	return p.parts[i]
+}
+
+// Reader returns a new reader on partition i, see Writer.Reader.
+func (p *Partitioned[T]) Reader(i int) *Reader[T] {
+	return p.parts[i].Reader()
+}
+
+// BlockingReader returns a new blocking reader on partition i, see
+// Writer.BlockingReader.
+func (p *Partitioned[T]) BlockingReader(i int) *Reader[T] {
+	return p.parts[i].BlockingReader()
+}
+
+// Close closes all partitions, and waits until all their readers are closed.
+func (p *Partitioned[T]) Close() error {
+	closeAll(p.parts)
+	return nil
+}
+
+// closeAll closes writers concurrently, and waits until all their readers are
+// closed.
+func closeAll[T any](writers []*Writer[T]) {
+	var wg sync.WaitGroup
+	for _, w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Close()
+		}()
+	}
+	wg.Wait()
+}
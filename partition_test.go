@@ -0,0 +1,43 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPartitioned(t *testing.T) {
+	type event struct {
+		conn uint64
+		seq  int
+	}
+
+	p, err := NewPartitioned(3, 16, func(e event) uint64 { return e.conn })
+	if err != nil {
+		t.Errorf("failed to initialize partitions")
+		return
+	}
+	if _, err := NewPartitioned(0, 16, func(e event) uint64 { return 0 }); err == nil {
+		t.Errorf("failed partition count test, expected error")
+	}
+
+	n, err := p.Append(event{1, 0}, event{4, 0}, event{2, 0}, event{1, 1}, event{4, 1}, event{5, 0})
+	if n != 6 || err != nil {
+		t.Errorf("failed partitioned write test, got n=%d err=%v", n, err)
+	}
+
+	// conns 1 and 4 share partition 1
+	r := p.Reader(1)
+	defer r.Close()
+	buf := make([]event, 8)
+	n, _ = r.Read(buf)
+	if exp := []event{{1, 0}, {4, 0}, {1, 1}, {4, 1}}; !slices.Equal(buf[:n], exp) {
+		t.Errorf("failed partitioned read test, expected %v, got %v", exp, buf[:n])
+	}
+
+	if p.Partition(2).TotalWritten() != 2 || p.Partition(0).TotalWritten() != 0 {
+		t.Errorf("failed partition routing test")
+	}
+
+	go r.Close()
+	p.Close()
+}
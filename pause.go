@@ -0,0 +1,36 @@
+package ringslice
+
+import "errors"
+
+// ErrPaused is returned by writes on a paused writer using the Reject policy.
+var ErrPaused = errors.New("ringbuffer writer is paused")
+
+// Pause causes subsequent writes, including WriteAt and Update, to wait until
+// Resume is called, or to return ErrPaused if the writer's policy is Reject.
+// Writes already copying data complete before Pause returns, while writes
+// waiting for pinned readers to make room (see Block) keep waiting until
+// Resume is called, even if room is made. Readers are not affected and can
+// still read the data retained in the buffer.
+func (w *Writer[T]) Pause() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.paused = true
+}
+
+// Resume lets writes waiting because of Pause continue.
+func (w *Writer[T]) Resume() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.paused = false
+	w.space.Broadcast()
+}
+
+// Paused returns true if the writer is paused, see Pause.
+func (w *Writer[T]) Paused() bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.paused
+}
@@ -0,0 +1,125 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPause(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.BlockingReader()
+	defer r.Close()
+	w.Append(1)
+	w.Pause()
+	if !w.Paused() {
+		t.Errorf("failed pause test, writer should be paused")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.Append(2)
+		w.WriteAt(0, 10)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Errorf("failed pause test, write should wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// readers are not affected
+	if v, err := r.ReadOne(); v != 1 || err != nil {
+		t.Errorf("failed pause test, expected 1, got %d err=%v", v, err)
+	}
+
+	w.Resume()
+	<-done
+	if s := w.Snapshot(); !slices.Equal(s, []int{10, 2}) {
+		t.Errorf("failed resume test, expected [10 2], got %v", s)
+	}
+}
+
+func TestPauseBlocked(t *testing.T) {
+	w, err := New[int](2, WithFullPolicy(Block))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2)
+
+	// a write waiting for room stays paused once room is made
+	done := make(chan struct{})
+	go func() {
+		w.Append(3)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	w.Pause()
+	if v, err := r.ReadOne(); v != 1 || err != nil {
+		t.Errorf("failed paused blocked write test, expected 1, got %d err=%v", v, err)
+	}
+	select {
+	case <-done:
+		t.Errorf("failed paused blocked write test, write should wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	w.Resume()
+	<-done
+	if s := w.Snapshot(); !slices.Equal(s, []int{2, 3}) {
+		t.Errorf("failed paused blocked write test, expected [2 3], got %v", s)
+	}
+}
+
+func TestPauseReject(t *testing.T) {
+	w, err := New[int](8, WithFullPolicy(Reject))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Pause()
+	if _, err := w.Append(1); err != ErrPaused {
+		t.Errorf("failed pause test, expected ErrPaused, got %v", err)
+	}
+	w.Do(func(tx *Tx[int]) {
+		if _, err := tx.Append(1); err != ErrPaused {
+			t.Errorf("failed pause test, expected ErrPaused, got %v", err)
+		}
+	})
+	w.Resume()
+	if _, err := w.Append(1); err != nil {
+		t.Errorf("failed resume test, expected nil, got %v", err)
+	}
+
+}
+
+func TestPauseClose(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	// closing unblocks paused writes
+	w.Pause()
+	done := make(chan error)
+	go func() {
+		_, err := w.Append(2)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	w.Close()
+	if err := <-done; err == nil {
+		t.Errorf("failed closed pause test, expected an error")
+	}
+}
@@ -0,0 +1,330 @@
+package ringslice
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Persistent is a buffer whose appends are mirrored to a write-ahead log
+// file, so the retained elements can be recovered after a crash or a
+// restart, see OpenPersistent. Only appends are supported, as other
+// modifications of the buffer would not be logged.
+type Persistent[T any] struct {
+	w *Writer[T]
+
+	path   string
+	f      *os.File
+	enc    func(T) ([]byte, error)
+	sync   bool
+	logged int64 // elements appended to the log since the last compaction
+	mu     sync.Mutex
+}
+
+// OpenPersistent opens or creates a persistent buffer of the given size
+// stored in the file at path. If the file exists, the buffer's positions and
+// retained elements are recovered from it, ignoring any partially written
+// record at the end of the file.
+//
+// The file starts with a state snapshot (see SaveState) followed by a log of
+// appends, and is periodically rewritten as a new snapshot to keep its size
+// bounded.
+func OpenPersistent[T any](path string, size int64, enc func(T) ([]byte, error), dec func([]byte) (T, error)) (*Persistent[T], error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Persistent[T]{
+		path: path,
+		f:    f,
+		enc:  enc,
+	}
+
+	if p.w, err = p.recover(size, dec); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// start from a fresh snapshot, dropping any torn record
+	if err = p.compact(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// recover loads the state stored in the file, if any.
+func (p *Persistent[T]) recover(size int64, dec func([]byte) (T, error)) (*Writer[T], error) {
+	st, err := p.f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if st.Size() == 0 {
+		return New[T](size)
+	}
+
+	in := bufio.NewReader(p.f)
+	w, err := loadState(in, dec)
+	if err != nil {
+		return nil, err
+	}
+
+	// replay log records until the end of the file or a torn record
+	var elements []T
+	for {
+		elements, err = readRecord(in, dec, elements[:0])
+		if err != nil {
+			break
+		}
+		w.Write(elements)
+		p.logged += int64(len(elements))
+	}
+
+	if w.Size() != size {
+		// keep as much of the recovered data as possible
+		nw, err := New[T](size)
+		if err != nil {
+			return nil, err
+		}
+		head := w.TotalWritten()
+		snap := w.Snapshot()
+		snap = snap[max(int64(len(snap))-size, 0):]
+		nw.data.copyIn(head-int64(len(snap)), snap)
+		nw.restore(head, head-int64(len(snap)))
+		w = nw
+	}
+
+	return w, nil
+}
+
+// Append values to the buffer and the log.
+func (p *Persistent[T]) Append(values ...T) (int, error) {
+	return p.Write(values)
+}
+
+// Write appends values to the log, then to the buffer. If the buffer does
+// not accept all values, only those it accepted are kept in the log.
+func (p *Persistent[T]) Write(values []T) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	off, err := p.log(values)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := p.w.Write(values)
+	if err != nil {
+		p.rewind(off)
+		if n > 0 {
+			p.log(values[:n])
+		}
+		return n, err
+	}
+
+	p.logged += int64(n)
+	if p.logged > 2*p.w.Size() {
+		return n, p.compact()
+	}
+	return n, nil
+}
+
+// log appends a record holding values to the log, returning the offset it
+// was written at.
+func (p *Persistent[T]) log(values []T) (int64, error) {
+	rec, err := appendRecord(nil, values, p.enc)
+	if err != nil {
+		return 0, err
+	}
+
+	off, err := p.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = p.f.Write(rec); err == nil && p.sync {
+		err = p.f.Sync()
+	}
+	if err != nil {
+		// do not leave a partial record behind
+		p.rewind(off)
+		return 0, err
+	}
+	return off, nil
+}
+
+// rewind truncates the log at off.
+func (p *Persistent[T]) rewind(off int64) {
+	p.f.Truncate(off)
+	p.f.Seek(off, io.SeekStart)
+}
+
+// Reader returns a new reader on the buffer, see Writer.Reader.
+func (p *Persistent[T]) Reader() *Reader[T] {
+	return p.w.Reader()
+}
+
+// BlockingReader returns a new blocking reader on the buffer, see
+// Writer.BlockingReader.
+func (p *Persistent[T]) BlockingReader() *Reader[T] {
+	return p.w.BlockingReader()
+}
+
+// NewReader returns a new reader on the buffer, see Writer.NewReader.
+func (p *Persistent[T]) NewReader() (*Reader[T], error) {
+	return p.w.NewReader()
+}
+
+// NewBlockingReader returns a new blocking reader on the buffer, see
+// Writer.NewBlockingReader.
+func (p *Persistent[T]) NewBlockingReader() (*Reader[T], error) {
+	return p.w.NewBlockingReader()
+}
+
+// Snapshot returns a copy of the elements retained by the buffer.
+func (p *Persistent[T]) Snapshot() []T {
+	return p.w.Snapshot()
+}
+
+// TotalWritten returns the total number of elements ever written.
+func (p *Persistent[T]) TotalWritten() int64 {
+	return p.w.TotalWritten()
+}
+
+// Size returns the size of the buffer.
+func (p *Persistent[T]) Size() int64 {
+	return p.w.Size()
+}
+
+// SetSync enables calling fsync after each write, ensuring appended data is
+// on disk before Write returns.
+func (p *Persistent[T]) SetSync(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sync = enabled
+}
+
+// Close closes the log file and the writer, see Writer.Close.
+func (p *Persistent[T]) Close() error {
+	p.mu.Lock()
+	err := p.f.Sync()
+	if cerr := p.f.Close(); err == nil {
+		err = cerr
+	}
+	p.mu.Unlock()
+
+	if cerr := p.w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// compact atomically replaces the file with a snapshot of the current state.
+func (p *Persistent[T]) compact() error {
+	err := writeFileAtomic(p.path, func(out io.Writer) error {
+		return p.w.SaveState(out, p.enc)
+	})
+	if err == nil {
+		// make the rename itself durable
+		err = syncDir(filepath.Dir(p.path))
+	}
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p.path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return err
+	}
+
+	p.f.Close()
+	p.f = f
+	p.logged = 0
+	return nil
+}
+
+// syncDir flushes the directory at path to disk.
+func syncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// appendRecord appends a log record holding values to buf. A record is made
+// of its payload length, a CRC32 of the payload, and the payload itself
+// which holds the number of elements followed by each encoded element.
+func appendRecord[T any](buf []byte, values []T, enc func(T) ([]byte, error)) ([]byte, error) {
+	payload := binary.AppendUvarint(nil, uint64(len(values)))
+	for _, v := range values {
+		data, err := enc(v)
+		if err != nil {
+			return nil, err
+		}
+		payload = binary.AppendUvarint(payload, uint64(len(data)))
+		payload = append(payload, data...)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(payload)))
+	buf = binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(payload))
+	return append(buf, payload...), nil
+}
+
+// readRecord reads a record written by appendRecord, appending its elements
+// to res.
+func readRecord[T any](in stateReader, dec func([]byte) (T, error), res []T) ([]T, error) {
+	l, err := binary.ReadUvarint(in)
+	if err != nil {
+		return nil, err
+	}
+	if l > maxStateElement {
+		return nil, ErrInvalidState
+	}
+
+	buf := make([]byte, 4+l)
+	if _, err = io.ReadFull(in, buf); err != nil {
+		return nil, err
+	}
+	payload := buf[4:]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(buf) {
+		return nil, ErrInvalidState
+	}
+
+	count, n := binary.Uvarint(payload)
+	if n <= 0 {
+		return nil, ErrInvalidState
+	}
+	payload = payload[n:]
+
+	for ; count > 0; count-- {
+		el, n := binary.Uvarint(payload)
+		if n <= 0 || uint64(len(payload)-n) < el {
+			return nil, ErrInvalidState
+		}
+		v, err := dec(payload[n : n+int(el)])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+		payload = payload[n+int(el):]
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package ringslice
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestPersistent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	enc := func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil }
+	dec := func(b []byte) (int, error) { return strconv.Atoi(string(b)) }
+
+	p, err := OpenPersistent(path, 4, enc, dec)
+	if err != nil {
+		t.Errorf("failed to open persistent buffer, got err=%v", err)
+		return
+	}
+
+	for i := 1; i <= 10; i++ {
+		p.Append(i)
+	}
+	p.Append(11, 12)
+
+	// simulate a crash in the middle of a write
+	p.f.Write([]byte{0x10, 1, 2})
+	p.f.Close()
+
+	p, err = OpenPersistent(path, 4, enc, dec)
+	if err != nil {
+		t.Errorf("failed to reopen persistent buffer, got err=%v", err)
+		return
+	}
+
+	if s := p.Snapshot(); p.TotalWritten() != 12 || !slices.Equal(s, []int{9, 10, 11, 12}) {
+		t.Errorf("failed persistent recovery test, got written=%d data=%v", p.TotalWritten(), s)
+	}
+
+	p.Append(13)
+	p.Close()
+
+	// reopen with a smaller size
+	p, err = OpenPersistent(path, 2, enc, dec)
+	if err != nil {
+		t.Errorf("failed to reopen persistent buffer, got err=%v", err)
+		return
+	}
+	defer p.Close()
+
+	if s := p.Snapshot(); p.TotalWritten() != 13 || !slices.Equal(s, []int{12, 13}) {
+		t.Errorf("failed persistent resize test, got written=%d data=%v", p.TotalWritten(), s)
+	}
+
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("failed persistent test, temporary file left behind")
+	}
+}
+
+func TestPersistentWriteError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	enc := func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil }
+
+	p, err := OpenPersistent(path, 4, enc, nil)
+	if err != nil {
+		t.Errorf("failed to open persistent buffer, got err=%v", err)
+		return
+	}
+	defer p.Close()
+
+	st, _ := p.f.Stat()
+	p.w.closeWithError(nil)
+	if _, err := p.Append(1); err == nil {
+		t.Errorf("failed persistent write error test, expected error")
+	}
+	if st2, _ := p.f.Stat(); st2.Size() != st.Size() {
+		t.Errorf("failed persistent write error test, expected log size %d, got %d", st.Size(), st2.Size())
+	}
+}
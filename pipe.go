@@ -0,0 +1,83 @@
+package ringslice
+
+import "io"
+
+// PipeReader is the read half of a pipe, see Pipe.
+type PipeReader[T any] struct {
+	r *Reader[T]
+}
+
+// PipeWriter is the write half of a pipe, see Pipe.
+type PipeWriter[T any] struct {
+	w *Writer[T]
+}
+
+// Pipe creates a buffered in-memory pipe of the given size. Similar to
+// io.Pipe, reads block until data is available and writes block when the
+// buffer is full until the reader has consumed enough data, but up to size
+// elements can be written without waiting for the reader.
+func Pipe[T any](size int64) (*PipeReader[T], *PipeWriter[T], error) {
+	w, err := New[T](size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := w.BlockingReader()
+
+	w.mutex.Lock()
+	w.pin(r)
+	w.mutex.Unlock()
+
+	return &PipeReader[T]{r}, &PipeWriter[T]{w}, nil
+}
+
+// Read reads data from the pipe, blocking until data is available or the
+// write half is closed. Once the write half is closed and all data has been
+// read, Read returns the error passed to CloseWithError, or io.EOF.
+func (p *PipeReader[T]) Read(data []T) (int, error) {
+	return p.r.Read(data)
+}
+
+// Close closes the reader. Subsequent writes to the write half of the pipe
+// will return io.ErrClosedPipe.
+func (p *PipeReader[T]) Close() error {
+	return p.CloseWithError(nil)
+}
+
+// CloseWithError closes the reader. Subsequent writes to the write half of
+// the pipe will return err, or io.ErrClosedPipe if err is nil.
+func (p *PipeReader[T]) CloseWithError(err error) error {
+	if err == nil {
+		err = io.ErrClosedPipe
+	}
+
+	w := p.r.w
+	w.mutex.Lock()
+	if w.werr == nil {
+		w.werr = err
+	}
+	w.mutex.Unlock()
+
+	// like io.PipeReader, closing more than once is not an error
+	p.r.Close()
+	return nil
+}
+
+// Write writes data to the pipe, blocking until all of it has been stored in
+// the buffer, or the read half is closed.
+func (p *PipeWriter[T]) Write(data []T) (int, error) {
+	return p.w.Write(data)
+}
+
+// Close closes the writer. Once all data has been read, reads from the read
+// half of the pipe will return io.EOF.
+func (p *PipeWriter[T]) Close() error {
+	return p.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer. Once all data has been read, reads from
+// the read half of the pipe will return err, or io.EOF if err is nil.
+func (p *PipeWriter[T]) CloseWithError(err error) error {
+	p.w.closeWithError(err)
+	return nil
+}
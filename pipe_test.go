@@ -0,0 +1,62 @@
+package ringslice
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	pr, pw, err := Pipe[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize pipe")
+		return
+	}
+
+	errTest := errors.New("test error")
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i += 10 {
+			// larger than the buffer, will block until the reader catches up
+			values := []int{i, i + 1, i + 2, i + 3, i + 4, i + 5, i + 6, i + 7, i + 8, i + 9}
+			if n, err := pw.Write(values); n != 10 || err != nil {
+				t.Errorf("failed pipe write, got n=%d err=%v", n, err)
+			}
+		}
+		pw.CloseWithError(errTest)
+	}()
+
+	rbuf := make([]int, 3)
+	next := 0
+	for {
+		n, err := pr.Read(rbuf)
+		if err != nil {
+			if err != errTest {
+				t.Errorf("failed pipe close test, expected test error, got err=%v", err)
+			}
+			break
+		}
+		for _, v := range rbuf[:n] {
+			if v != next {
+				t.Errorf("failed pipe read, expected %d, got %d", next, v)
+			}
+			next = v + 1
+		}
+	}
+	<-done
+
+	if next != 100 {
+		t.Errorf("failed pipe test, expected to read 100 values, got %d", next)
+	}
+
+	// closing the read half
+	pr, pw, _ = Pipe[int](4)
+	pw.Write([]int{1, 2, 3, 4})
+	go pr.Close()
+
+	if _, err = pw.Write([]int{5}); err != io.ErrClosedPipe {
+		t.Errorf("failed pipe reader close test, expected io.ErrClosedPipe, got err=%v", err)
+	}
+}
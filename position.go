@@ -0,0 +1,139 @@
+package ringslice
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+var ErrNoPositionStore = errors.New("reader was not created with a position store")
+
+// PositionStore persists reader positions by name, see ResumeReader.
+type PositionStore interface {
+	// LoadPosition returns the position saved for name, and false if there
+	// is none.
+	LoadPosition(name string) (int64, bool, error)
+	// SavePosition saves the position of the reader with the given name.
+	SavePosition(name string, pos int64) error
+}
+
+// PositionFuncs is a PositionStore calling user functions.
+type PositionFuncs struct {
+	Load func(name string) (int64, bool, error)
+	Save func(name string, pos int64) error
+}
+
+func (f PositionFuncs) LoadPosition(name string) (int64, bool, error) { return f.Load(name) }
+
+func (f PositionFuncs) SavePosition(name string, pos int64) error { return f.Save(name, pos) }
+
+// FilePositionStore is a PositionStore keeping positions in a JSON file,
+// which is replaced atomically on each save.
+type FilePositionStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFilePositionStore returns a store keeping positions in the file at
+// path, which is created on the first save.
+func NewFilePositionStore(path string) *FilePositionStore {
+	return &FilePositionStore{path: path}
+}
+
+func (s *FilePositionStore) load() (map[string]int64, error) {
+	res := make(map[string]int64)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *FilePositionStore) LoadPosition(name string) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pos, err := s.load()
+	if err != nil {
+		return 0, false, err
+	}
+	p, ok := pos[name]
+	return p, ok, nil
+}
+
+func (s *FilePositionStore) SavePosition(name string, p int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pos, err := s.load()
+	if err != nil {
+		return err
+	}
+	pos[name] = p
+	return writeFileAtomic(s.path, func(out io.Writer) error {
+		return json.NewEncoder(out).Encode(pos)
+	})
+}
+
+// ResumeReader returns a new reader with the given name, positioned where a
+// reader of the same name was when its position was last saved to store with
+// SavePosition, or at the oldest available position if none was saved. It
+// returns ErrWriterClosed if the writer is closed.
+//
+// A saved position older than the retained data makes the reader stale, so
+// missed data is reported as with any other reader. If the reader is pinned
+// by the writer's full policy, it starts at the oldest retained position
+// instead, with the missed range reported by Gaps.
+func (w *Writer[T]) ResumeReader(name string, store PositionStore) (*Reader[T], error) {
+	pos, ok, err := store.LoadPosition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := w.newReaderErr(w.blocking, false)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	r.name = name
+	r.posStore = store
+	if ok {
+		// the buffer cannot be behind a saved position
+		head := w.head.Load()
+		pos = min(pos, head)
+		if oldest := w.oldest(head); r.pinned && pos < oldest {
+			// a pinned reader must not be behind the retained data
+			r.addGap(pos, oldest)
+			pos = oldest
+		}
+		r.pos.Store(pos)
+	}
+	return r, nil
+}
+
+// SavePosition saves the position of the reader to the store it was created
+// with by ResumeReader. It should be called once the data read so far has
+// been processed, so a restarted consumer resumes after it. If a commit hook
+// is set (see SetCommitHook), it is called first and can fail the save.
+func (r *Reader[T]) SavePosition() error {
+	if r.posStore == nil {
+		return ErrNoPositionStore
+	}
+	// elements fetched by ReadOne but not returned yet are not consumed
+	name, pos := r.Name(), r.pos.Load()-int64(len(r.ahead))
+	if err := r.w.onCommit(name, pos); err != nil {
+		return err
+	}
+	return r.posStore.SavePosition(name, pos)
+}
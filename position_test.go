@@ -0,0 +1,87 @@
+package ringslice
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestResumeReader(t *testing.T) {
+	store := NewFilePositionStore(filepath.Join(t.TempDir(), "positions.json"))
+
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2, 3, 4, 5)
+
+	r, err := w.ResumeReader("consumer", store)
+	if err != nil {
+		t.Errorf("failed resume reader test, got err=%v", err)
+		return
+	}
+	if v, _ := r.ReadOne(); v != 1 {
+		t.Errorf("failed resume reader test, expected 1, got %d", v)
+	}
+	r.ReadOne()
+	if err := r.SavePosition(); err != nil {
+		t.Errorf("failed save position test, got err=%v", err)
+	}
+	r.ReadOne() // not saved
+	r.Close()
+
+	r, _ = w.ResumeReader("consumer", store)
+	defer r.Close()
+	if v, _ := r.ReadOne(); v != 3 || r.Name() != "consumer" {
+		t.Errorf("failed resume reader test, expected 3, got %d", v)
+	}
+
+	plain := w.Reader()
+	defer plain.Close()
+	if err := plain.SavePosition(); err != ErrNoPositionStore {
+		t.Errorf("failed save position test, expected ErrNoPositionStore, got %v", err)
+	}
+
+	var saved int64
+	funcs := PositionFuncs{
+		Load: func(string) (int64, bool, error) { return 100, true, nil },
+		Save: func(_ string, pos int64) error { saved = pos; return nil },
+	}
+	r2, _ := w.ResumeReader("other", funcs)
+	defer r2.Close()
+	r2.SavePosition()
+	if saved != 5 {
+		t.Errorf("failed position funcs test, expected 5, got %d", saved)
+	}
+}
+
+func TestResumeReaderPinned(t *testing.T) {
+	w, err := New[int](4, WithFullPolicy(Reject))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	// saved before 1 and 2 were overwritten
+	funcs := PositionFuncs{
+		Load: func(string) (int64, bool, error) { return 0, true, nil },
+		Save: func(string, int64) error { return nil },
+	}
+	r, err := w.ResumeReader("pinned", funcs)
+	if err != nil {
+		t.Errorf("failed pinned resume test, got err=%v", err)
+		return
+	}
+	defer r.Close()
+	if v, err := r.ReadOne(); v != 3 || err != nil {
+		t.Errorf("failed pinned resume test, expected 3, got %d err=%v", v, err)
+	}
+	if gaps := r.Gaps(); !slices.Equal(gaps, [][2]int64{{0, 2}}) {
+		t.Errorf("failed pinned resume gaps test, expected [[0 2]], got %v", gaps)
+	}
+	if _, err := w.Append(7); err != nil {
+		t.Errorf("failed pinned resume write test, expected nil, got %v", err)
+	}
+}
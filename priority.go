@@ -0,0 +1,59 @@
+package ringslice
+
+import "errors"
+
+// PriorityRing is a set of buffers, or lanes, holding elements of different
+// priority classes. Its readers drain higher priority lanes first, while
+// elements of a given lane are read in order. Lane 0 has the highest
+// priority.
+type PriorityRing[T any] struct {
+	lanes []*Writer[T]
+}
+
+// NewPriorityRing returns a ring with the given number of priority lanes,
+// each able to hold size elements.
+func NewPriorityRing[T any](lanes int, size int64) (*PriorityRing[T], error) {
+	if lanes <= 0 {
+		return nil, errors.New("Lane count must be positive")
+	}
+
+	p := &PriorityRing[T]{lanes: make([]*Writer[T], lanes)}
+	for i := range p.lanes {
+		w, err := New[T](size)
+		if err != nil {
+			return nil, err
+		}
+		p.lanes[i] = w
+	}
+	return p, nil
+}
+
+// Write writes values to the lane of the given priority, 0 being the
+// highest.
+func (p *PriorityRing[T]) Write(priority int, values []T) (int, error) {
+	return p.lanes[priority].Write(values)
+}
+
+// Append writes values to the lane of the given priority.
+func (p *PriorityRing[T]) Append(priority int, values ...T) (int, error) {
+	return p.Write(priority, values)
+}
+
+// Lane returns the buffer of the given priority.
+func (p *PriorityRing[T]) Lane(priority int) *Writer[T] {
+	return p.lanes[priority]
+}
+
+// Reader returns a new blocking reader over all lanes, which always returns
+// data from the highest priority lane having data available.
+func (p *PriorityRing[T]) Reader() *MergeReader[T] {
+	m := NewMergeReader(p.lanes...)
+	m.ordered = true
+	return m
+}
+
+// Close closes all lanes, and waits until all their readers are closed.
+func (p *PriorityRing[T]) Close() error {
+	closeAll(p.lanes)
+	return nil
+}
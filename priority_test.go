@@ -0,0 +1,40 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPriorityRing(t *testing.T) {
+	p, err := NewPriorityRing[string](2, 8)
+	if err != nil {
+		t.Errorf("failed to initialize priority ring")
+		return
+	}
+
+	r := p.Reader()
+	defer r.Close()
+
+	p.Append(1, "bulk1", "bulk2")
+	p.Append(0, "ctl1")
+	p.Append(1, "bulk3")
+	p.Append(0, "ctl2")
+
+	var res []string
+	buf := make([]string, 1)
+	for i := 0; i < 5; i++ {
+		n, err := r.Read(buf)
+		if err != nil {
+			t.Errorf("failed priority ring test, got err=%v", err)
+			return
+		}
+		res = append(res, buf[:n]...)
+	}
+	if exp := []string{"ctl1", "ctl2", "bulk1", "bulk2", "bulk3"}; !slices.Equal(res, exp) {
+		t.Errorf("failed priority ring test, expected %v, got %v", exp, res)
+	}
+
+	if _, err := NewPriorityRing[string](0, 8); err == nil {
+		t.Errorf("failed lane count test, expected error")
+	}
+}
@@ -0,0 +1,86 @@
+package ringslice
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Fold calls fn for each element retained in w from oldest to newest, passing
+// the result of the previous call (or init for the first one), and returns
+// the result of the last call. Elements are not copied, and w is locked for
+// reading during the whole operation so fn must not use w.
+func Fold[T, A any](w *Writer[T], init A, fn func(A, T) A) A {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	res := init
+	w.each(0, func(_ int64, v T) bool {
+		res = fn(res, v)
+		return true
+	})
+	return res
+}
+
+// SnapshotSorted returns a copy of all the elements currently retained in
+// the buffer, sorted according to less. Equal elements are kept in write
+// order.
+func (w *Writer[T]) SnapshotSorted(less func(a, b T) bool) []T {
+	res := w.Snapshot()
+	slices.SortStableFunc(res, cmpFunc(less))
+	return res
+}
+
+// cmpFunc returns a comparison function as used by the slices package from
+// a less function.
+func cmpFunc[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+// TopK returns the k largest elements retained in the buffer according to
+// less, from largest to smallest. Only k elements are kept in memory while
+// scanning the buffer, which is locked for reading.
+func (w *Writer[T]) TopK(k int, less func(a, b T) bool) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	h := &topHeap[T]{less: less}
+	w.mutex.RLock()
+	w.each(0, func(_ int64, v T) bool {
+		if len(h.values) < k {
+			heap.Push(h, v)
+		} else if less(h.values[0], v) {
+			h.values[0] = v
+			heap.Fix(h, 0)
+		}
+		return true
+	})
+	w.mutex.RUnlock()
+
+	slices.SortStableFunc(h.values, cmpFunc(func(a, b T) bool { return less(b, a) }))
+	return h.values
+}
+
+// topHeap is a min-heap keeping the largest elements seen.
+type topHeap[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (h *topHeap[T]) Len() int           { return len(h.values) }
+func (h *topHeap[T]) Less(i, j int) bool { return h.less(h.values[i], h.values[j]) }
+func (h *topHeap[T]) Swap(i, j int)      { h.values[i], h.values[j] = h.values[j], h.values[i] }
+func (h *topHeap[T]) Push(x any)         { h.values = append(h.values, x.(T)) }
+func (h *topHeap[T]) Pop() any {
+	v := h.values[len(h.values)-1]
+	h.values = h.values[:len(h.values)-1]
+	return v
+}
@@ -0,0 +1,77 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	w, err := New[string](3)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append("x", "a", "bb", "a")
+
+	counts := Fold(w, map[string]int{}, func(m map[string]int, v string) map[string]int {
+		m[v]++
+		return m
+	})
+	if len(counts) != 2 || counts["a"] != 2 || counts["bb"] != 1 {
+		t.Errorf("failed fold test, got %v", counts)
+	}
+
+	if l := Fold(w, 0, func(n int, v string) int { return n + len(v) }); l != 4 {
+		t.Errorf("failed fold test, expected 4, got %d", l)
+	}
+}
+
+func TestSnapshotSorted(t *testing.T) {
+	type entry struct {
+		name  string
+		value int
+	}
+
+	w, err := New[entry](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append(entry{"x", 100}, entry{"a", 3}, entry{"b", 7}, entry{"c", 3}, entry{"d", 9})
+
+	res := w.SnapshotSorted(func(a, b entry) bool { return a.value > b.value })
+	names := ""
+	for _, e := range res {
+		names += e.name
+	}
+	if names != "dbac" {
+		t.Errorf("failed sorted snapshot test, expected dbac, got %s", names)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	w, err := New[int](6)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	less := func(a, b int) bool { return a < b }
+	if res := w.TopK(3, less); len(res) != 0 {
+		t.Errorf("failed empty top test, got %v", res)
+	}
+
+	w.Append(100, 5, 1, 9, 3, 7, 2)
+
+	if res := w.TopK(3, less); !slices.Equal(res, []int{9, 7, 5}) {
+		t.Errorf("failed top test, expected [9 7 5], got %v", res)
+	}
+	if res := w.TopK(10, less); !slices.Equal(res, []int{9, 7, 5, 3, 2, 1}) {
+		t.Errorf("failed top all test, expected [9 7 5 3 2 1], got %v", res)
+	}
+	if res := w.TopK(0, less); res != nil {
+		t.Errorf("failed top zero test, got %v", res)
+	}
+}
@@ -0,0 +1,77 @@
+package ringslice
+
+import (
+	"sync"
+	"time"
+)
+
+// WorkQueue distributes the elements of a buffer among competing consumers:
+// each element is delivered to exactly one caller of Take or TakeOne, which
+// can be called concurrently by any number of worker goroutines.
+//
+// The queue holds its position on the buffer, so writes block once the
+// buffer is full of elements not taken yet instead of overwriting them.
+type WorkQueue[T any] struct {
+	r  *Reader[T]
+	mu sync.Mutex // serializes reads
+
+	// items delivered by Claim and not acknowledged, see Ack
+	amu     sync.Mutex
+	timeout time.Duration
+	claims  map[int64]*claimed[T]
+	retry   []int64 // nacked items, in order
+}
+
+// WorkQueue returns a new work queue starting at the buffer's oldest
+// available position. It returns nil if the writer is closed, see
+// NewWorkQueue.
+func (w *Writer[T]) WorkQueue() *WorkQueue[T] {
+	q, _ := w.NewWorkQueue()
+	return q
+}
+
+// NewWorkQueue is like WorkQueue, but returns ErrWriterClosed instead of a
+// nil queue if the writer is closed.
+func (w *Writer[T]) NewWorkQueue() (*WorkQueue[T], error) {
+	r, err := w.NewBlockingReader()
+	if err != nil {
+		return nil, err
+	}
+	r.SetReadAhead(1)
+
+	w.mutex.Lock()
+	w.pin(r)
+	w.mutex.Unlock()
+
+	return &WorkQueue[T]{r: r}, nil
+}
+
+// Take fills p with elements not delivered to any other worker, blocking
+// until at least one is available. Once the writer is closed and all
+// elements were taken, Take returns io.EOF.
+func (q *WorkQueue[T]) Take(p []T) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.r.Read(p)
+}
+
+// TakeOne returns a single element not delivered to any other worker,
+// blocking until one is available.
+func (q *WorkQueue[T]) TakeOne() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.r.ReadOne()
+}
+
+// Close stops the queue. Workers blocked in Take return io.ErrClosedPipe,
+// and writes do not wait for the queue anymore.
+func (q *WorkQueue[T]) Close() error {
+	return q.r.Close()
+}
+
+// Pending returns the number of elements written but not taken yet.
+func (q *WorkQueue[T]) Pending() int64 {
+	return q.r.Lag()
+}
@@ -0,0 +1,162 @@
+package ringslice
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkQueue(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	q := w.WorkQueue()
+	defer q.Close()
+
+	const workers, jobs = 4, 1000
+	seen := make([]int, jobs)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, err := q.TakeOne()
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				seen[v]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < jobs; i++ {
+		if _, err := w.Append(i); err != nil {
+			t.Errorf("failed work queue test, got err=%v", err)
+		}
+	}
+	w.closeWithError(nil)
+	wg.Wait()
+
+	for i, n := range seen {
+		if n != 1 {
+			t.Errorf("failed work queue test, job %d delivered %d times", i, n)
+			return
+		}
+	}
+}
+
+func TestWorkQueueAck(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	w, err := New[string](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.SetClock(clock)
+	q := w.WorkQueue()
+	defer q.Close()
+	q.SetAckTimeout(time.Minute)
+
+	w.Append("a", "b")
+
+	s1, v1, _ := q.Claim()
+	s2, v2, _ := q.Claim()
+	if v1 != "a" || v2 != "b" {
+		t.Errorf("failed claim test, expected a b, got %s %s", v1, v2)
+	}
+	q.Ack(s1)
+	if err := q.Ack(s1); err != ErrUnknownItem {
+		t.Errorf("failed double ack test, expected ErrUnknownItem, got %v", err)
+	}
+
+	// a blocked worker gets nacked items
+	res := make(chan string)
+	go func() {
+		_, v, _ := q.Claim()
+		res <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Nack(s2)
+	if v := <-res; v != "b" {
+		t.Errorf("failed nack test, expected b, got %s", v)
+	}
+
+	// and timed out items
+	go func() {
+		_, v, _ := q.Claim()
+		res <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	clock.Advance(time.Minute)
+	if v := <-res; v != "b" {
+		t.Errorf("failed ack timeout test, expected b, got %s", v)
+	}
+
+	q.Ack(s2)
+	w.Append("c")
+	if _, v, _ := q.Claim(); v != "c" {
+		t.Errorf("failed claim test, expected c, got %s", v)
+	}
+}
+
+func TestWorkQueueClaimFramed(t *testing.T) {
+	w, err := NewFramed[int](8, 2)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	q := w.WorkQueue()
+	defer q.Close()
+
+	// each element of a frame has its own sequence number
+	w.Append(1, 2, 3, 4)
+	for i := int64(0); i < 4; i++ {
+		if seq, v, err := q.Claim(); seq != i || v != int(i)+1 || err != nil {
+			t.Errorf("failed framed claim test, expected %d at %d, got %d at %d err=%v", i+1, i, v, seq, err)
+		}
+		if err := q.Ack(i); err != nil {
+			t.Errorf("failed framed ack test, expected nil, got %v", err)
+		}
+	}
+}
+
+func TestWorkQueueClaimDeadline(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	q := w.WorkQueue()
+	defer q.Close()
+	q.SetAckTimeout(10 * time.Millisecond)
+
+	// the second claim waits until the first one times out
+	w.Append(1, 2)
+	s1, _, _ := q.Claim()
+	s2, _, _ := q.Claim()
+	q.Ack(s1)
+	q.Ack(s2)
+	time.Sleep(20 * time.Millisecond)
+
+	res := make(chan error, 1)
+	go func() {
+		_, err := q.TakeOne()
+		res <- err
+	}()
+	select {
+	case err := <-res:
+		t.Errorf("failed claim deadline test, TakeOne should wait, got %v", err)
+		return
+	case <-time.After(50 * time.Millisecond):
+	}
+	w.Append(3)
+	if err := <-res; err != nil {
+		t.Errorf("failed claim deadline test, expected nil, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package ringslice
+
+import (
+	"os"
+	"time"
+)
+
+// rateLimit is a token bucket limiting the number of elements a reader
+// consumes per second.
+type rateLimit struct {
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens
+	tokens float64
+	last   time.Time
+}
+
+// SetRateLimit limits the rate at which this reader consumes elements to
+// perSec elements per second, allowing bursts of up to burst elements. Reads
+// wait as needed to respect the limit, and return fewer elements than
+// requested if the limit allows. This is useful to replay history to a
+// downstream system without flooding it. A rate of zero or less removes the
+// limit. If the writer uses frames, burst is raised to at least one frame.
+func (r *Reader[T]) SetRateLimit(perSec float64, burst int) {
+	if perSec <= 0 {
+		r.limit = nil
+		return
+	}
+	// a smaller burst would never allow reading a frame
+	burst = max(burst, r.w.unit())
+	r.limit = &rateLimit{
+		rate:   perSec,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   r.w.clk().Now(),
+	}
+}
+
+// pace waits until at least one frame can be read under the rate limit,
+// and returns how many of want elements can be read.
+func (r *Reader[T]) pace(want int) (int, error) {
+	l := r.limit
+	clock := r.w.clk()
+	unit := float64(r.w.unit())
+	for {
+		now := clock.Now()
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= unit || want == 0 {
+			return int(r.w.floor(int64(min(want, int(l.tokens))))), nil
+		}
+
+		d := time.Duration((unit - l.tokens) / l.rate * float64(time.Second))
+		r.w.mutex.RLock()
+		deadline := r.deadline
+		r.w.mutex.RUnlock()
+		if !deadline.IsZero() && now.Add(d).After(deadline) {
+			sleep(clock, deadline.Sub(now))
+			return 0, os.ErrDeadlineExceeded
+		}
+		sleep(clock, d)
+	}
+}
@@ -0,0 +1,73 @@
+package ringslice
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	w, err := New[int](100)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	for i := 0; i < 100; i++ {
+		w.Append(i)
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	r.SetRateLimit(500, 10)
+
+	start := time.Now()
+	buf := make([]int, 100)
+	total := 0
+	for total < 30 {
+		n, err := r.Read(buf)
+		if err != nil {
+			t.Errorf("failed rate limit test, got err=%v", err)
+			return
+		}
+		if n > 10 {
+			t.Errorf("failed rate limit burst test, expected at most 10, got %d", n)
+		}
+		total += n
+	}
+	// 10 elements of burst, then 20 elements at 500/s
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Errorf("failed rate limit test, expected at least 40ms, got %s", d)
+	}
+
+	r.SetRateLimit(0.5, 1)
+	r.Read(buf)
+	r.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	if _, err := r.Read(buf); err != os.ErrDeadlineExceeded {
+		t.Errorf("failed rate limit deadline test, expected os.ErrDeadlineExceeded, got %v", err)
+	}
+
+	r.SetRateLimit(0, 0)
+	if n, _ := r.Read(buf); n != 100-total-1 {
+		t.Errorf("failed rate limit removal test, expected %d, got %d", 100-total-1, n)
+	}
+}
+
+func TestRateLimitFrames(t *testing.T) {
+	w, err := New[int](16, WithFrameSize(4))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2, 3, 4)
+
+	r := w.Reader()
+	defer r.Close()
+
+	// a burst smaller than a frame is raised to one frame
+	r.SetRateLimit(1000, 1)
+	r.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]int, 8)
+	if n, err := r.Read(buf); n != 4 || err != nil {
+		t.Errorf("failed rate limit frame test, expected 4, got %d err=%v", n, err)
+	}
+}
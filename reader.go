@@ -2,208 +2,564 @@ package ringslice
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"runtime/trace"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type Reader[T any] struct {
 	w        *Writer[T]
-	rPos     int64
-	cycle    int64
+	id       uint64
+	name     string       // protected by the writer's lock
+	stack    []byte       // creation stack, if leak checking is enabled
+	pos      atomic.Int64 // absolute read position
 	block    bool
-	autoSkip bool
+	autoSkip atomic.Bool
+	pinned   bool
+	strict   bool       // see SetStrictEOF
+	prio     int        // wake priority, see SetWakePriority
+	level    *wakeLevel // wake level for prio, nil for the default one
 	closed   *uint64
+	waited   atomic.Int64 // total time spent blocked, in nanoseconds
+	deadline time.Time    // protected by the writer's lock
+
+	// read-ahead window used by ReadOne, filled with a single lock
+	// acquisition and consumed without touching the writer
+	ahead     []T
+	aheadBuf  []T
+	readAhead int
+
+	limit    *rateLimit    // see SetRateLimit
+	posStore PositionStore // see ResumeReader
+	filter   func(T) bool  // see Subscribe
+	bounded  bool          // reads stop at until, see Replay
+	until    int64
+
+	gapLock sync.Mutex
+	gaps    [][2]int64 // missed ranges, see Gaps
 }
 
+// defaultReadAhead is the number of elements ReadOne fetches at once
+const defaultReadAhead = 64
+
 var (
 	ErrStaleReader = errors.New("ringbuffer reader is stale (didn't read fast enough - do you need a larger buffer?)")
+
+	// ErrNoData is returned instead of io.EOF by non-blocking readers with
+	// strict EOF enabled when no data is available yet, see SetStrictEOF.
+	ErrNoData = errors.New("no data available yet")
+
+	// ErrWriterClosed is returned when creating a reader on a closed
+	// writer. It matches io.ErrClosedPipe with errors.Is.
+	ErrWriterClosed = fmt.Errorf("ringbuffer writer is closed: %w", io.ErrClosedPipe)
+
+	// ErrReaderClosed is returned when closing a reader which was already
+	// closed. It matches io.ErrClosedPipe with errors.Is.
+	ErrReaderClosed = fmt.Errorf("ringbuffer reader is already closed: %w", io.ErrClosedPipe)
+
+	errReaderInFuture = errors.New("this should not happen, reader is in the future?")
 )
 
 // Read will read data from the ringbuffer to the provided buffer. If no
 // new data is available, Read() will either return io.EOF (a later call may
 // return new data), or block until data becomes available (if set blocking).
 func (r *Reader[T]) Read(p []T) (int, error) {
-	if *r.closed > 0 {
+	if r.isClosed() {
 		// you can't read from a reader after calling Close on it
 		return 0, io.ErrClosedPipe
 	}
 
-	n := int64(len(p))
+	if len(r.ahead) > 0 {
+		// serve data previously fetched by ReadOne first
+		n := copy(p, r.ahead)
+		r.ahead = r.ahead[n:]
+		return n, nil
+	}
 
-	r.w.mutex.RLock()
-	defer r.w.mutex.RUnlock()
+	return r.read(p)
+}
 
-	if r.block {
-		for r.cycle == r.w.cycle && r.rPos >= r.w.wPos {
-			if r.w.closed {
-				r.block = false
-				break
-			}
-			r.w.cond.Wait()
+func (r *Reader[T]) read(p []T) (int, error) {
+	if r.w.frame > 1 {
+		var err error
+		if p, err = r.frameBuf(p); err != nil {
+			return 0, err
+		}
+	}
+	if r.limit != nil {
+		allowed, err := r.pace(len(p))
+		if err != nil {
+			return 0, err
 		}
+		p = p[:allowed]
 	}
 
-	if r.cycle < r.w.cycle-1 {
-		if r.autoSkip {
-			// skip missed data, resume as far back as possible
-			r.cycle = r.w.cycle - 1
-			r.rPos = r.w.wPos
-		} else {
-			return 0, ErrStaleReader
+	n, err := r.fetch(p)
+	if r.limit != nil {
+		r.limit.tokens -= float64(n)
+	}
+	if n > 0 {
+		r.w.rsizes.add(n)
+		if h := r.w.instrument(); h != nil {
+			h.OnRead(r.id, n)
 		}
 	}
+	return n, err
+}
 
-	if r.cycle == r.w.cycle-1 {
-		// remaining bytes in buffer
-		if r.w.wPos > r.rPos {
-			if r.autoSkip {
-				// skip
-				r.rPos = r.w.wPos
-			} else {
-				return 0, ErrStaleReader
-			}
+// fetch copies available data to p, using the lock-free path if possible.
+func (r *Reader[T]) fetch(p []T) (int, error) {
+	if n, ok, err := r.readFast(p); ok {
+		return n, err
+	}
+
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	return r.fetchLocked(p, false)
+}
+
+// fetchLocked copies available data to p, waiting for data if the reader is
+// blocking unless nowait is true. The caller must hold the read lock.
+func (r *Reader[T]) fetchLocked(p []T, nowait bool) (int, error) {
+	for {
+		pos, head, err := r.next(nowait)
+		if err != nil {
+			return 0, err
 		}
 
-		avail := r.w.size - r.rPos
-		if avail >= n {
-			copy(p, r.w.data[r.rPos:r.rPos+n])
-			r.rPos += n
-			if r.rPos >= r.w.size {
-				// reached end of buffer
-				r.rPos = 0
-				r.cycle += 1
+		var n int64
+		if r.filter != nil {
+			var next int64
+			n, next = r.w.copyFiltered(p, pos, head, r.filter)
+			r.pos.Store(next)
+			r.release()
+		} else {
+			n = min(int64(len(p)), head-pos)
+			r.w.data.copyOut(p[:n], pos)
+			r.pos.Store(pos + n)
+			r.release()
+			if r.w.expires != nil {
+				// drop expired elements
+				n = r.w.filterExpired(p[:n], pos)
 			}
-			return int(n), nil
 		}
-
-		copy(p, r.w.data[r.rPos:])
-		r.rPos = 0
-		r.cycle += 1
-		nextN, err := r.Read(p[avail:])
-
-		return int(avail) + nextN, err
+		if n == 0 && len(p) > 0 {
+			// everything was filtered out, try again
+			continue
+		}
+		r.w.verify()
+		return int(n), nil
 	}
+}
 
-	if r.cycle != r.w.cycle {
-		return 0, errors.New("this should not happen, reader is in the future?")
+// next moves the reader to the next available data, waiting for it if the
+// reader is blocking unless nowait is true, and returns the reader's position
+// and the end of the data it can read. The caller must hold the read lock.
+func (r *Reader[T]) next(nowait bool) (pos, head int64, err error) {
+	if r.bounded && r.pos.Load() >= r.until {
+		return 0, 0, io.EOF
 	}
-
-	// easy
-	if r.rPos >= r.w.wPos {
-		// > shouldn't happen
-		return 0, io.EOF
+	head = r.w.head.Load()
+	if !nowait {
+		if head, err = r.wait(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if r.bounded {
+		head = min(head, r.until)
 	}
 
-	avail := r.w.wPos - r.rPos
-
-	if n > avail {
-		n = avail
+	pos = r.pos.Load()
+	if oldest := head - r.w.size; pos < oldest {
+		r.wentStale(oldest - pos)
+		if !r.autoSkip.Load() {
+			return 0, 0, ErrStaleReader
+		}
+		// skip missed data, resume as far back as possible
+		r.addGap(pos, oldest)
+		pos = oldest
 	}
+	if tail := r.w.tail.Load(); pos < tail {
+		// data was discarded by Truncate
+		pos = tail
+	}
+	if r.w.maxAge.Load() != 0 {
+		// skip expired data
+		pos = max(pos, r.w.live(head))
+	}
+	r.pos.Store(pos)
 
-	copy(p, r.w.data[r.rPos:r.rPos+n])
-	r.rPos += n
-	return int(n), nil
+	if pos > head {
+		return 0, 0, errReaderInFuture
+	}
+	if pos == head {
+		return 0, 0, r.eof()
+	}
+	return pos, head, nil
 }
 
-func (r *Reader[T]) ReadOne() (T, error) {
-	if *r.closed > 0 {
-		// you can't read from a reader after calling Close on it
-		return empty[T](), io.ErrClosedPipe
+// eof returns the error to return when no data is available. The caller must
+// hold the read lock.
+func (r *Reader[T]) eof() error {
+	if !r.w.closed {
+		if r.strict {
+			return ErrNoData
+		}
+		return io.EOF
+	}
+	if r.w.closeErr != nil {
+		return r.w.closeErr
 	}
+	return io.EOF
+}
 
-	r.w.mutex.RLock()
-	defer r.w.mutex.RUnlock()
+// release wakes writers waiting for this reader to make progress, if pinned,
+// and checks watermarks. The caller must hold the read lock.
+func (r *Reader[T]) release() {
+	if r.pinned {
+		r.w.space.Broadcast()
+	}
+	r.w.checkWatermarks()
+}
 
-	if r.block {
-		for r.cycle == r.w.cycle && r.rPos >= r.w.wPos {
-			if r.w.closed {
-				r.block = false
-				break
-			}
-			r.w.cond.Wait()
-		}
+// wait blocks until data is available at the reader's position if the reader
+// is blocking, and returns the writer's head. The caller must hold the read
+// lock.
+func (r *Reader[T]) wait() (int64, error) {
+	head := r.w.head.Load()
+	if !r.block {
+		return head, nil
 	}
 
-	if r.cycle < r.w.cycle-1 {
-		if r.autoSkip {
-			// skip missed data, resume as far back as possible
-			r.cycle = r.w.cycle - 1
-			r.rPos = r.w.wPos
-		} else {
-			return empty[T](), ErrStaleReader
+	clock := r.w.clk()
+	var timer Timer
+	var armed, blockedAt time.Time
+	var region *trace.Region
+	defer func() {
+		if timer != nil {
+			timer.Stop()
 		}
-	}
+		endRegion(region)
+		if h := r.w.instrument(); h != nil && !blockedAt.IsZero() {
+			h.OnBlockEnd(r.id, clock.Now().Sub(blockedAt))
+		}
+	}()
 
-	if r.cycle == r.w.cycle-1 {
-		// remaining bytes in buffer
-		if r.w.wPos > r.rPos {
-			if r.autoSkip {
-				// skip
-				r.rPos = r.w.wPos
-			} else {
-				return empty[T](), ErrStaleReader
+	for r.pos.Load() >= head {
+		if r.w.closed {
+			r.block = false
+			break
+		}
+		if r.isClosed() {
+			return head, io.ErrClosedPipe
+		}
+		if !r.deadline.IsZero() {
+			d := r.deadline.Sub(clock.Now())
+			if d <= 0 {
+				return head, os.ErrDeadlineExceeded
+			}
+			if !armed.Equal(r.deadline) {
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = clock.AfterFunc(d, r.w.wake)
+				armed = r.deadline
 			}
 		}
-
-		avail := r.w.size - r.rPos
-		if avail >= 1 {
-			res := r.w.data[r.rPos]
-			r.rPos += 1
-			if r.rPos >= r.w.size {
-				// reached end of buffer
-				r.rPos = 0
-				r.cycle += 1
+		start := clock.Now()
+		if blockedAt.IsZero() {
+			blockedAt = start
+			if h := r.w.instrument(); h != nil {
+				h.OnBlockStart(r.id)
 			}
-			return res, nil
+			region = r.w.traceRegion("ringslice.ReadWait")
 		}
+		r.w.blocked.Add(1)
+		r.sleep()
+		r.waited.Add(int64(clock.Now().Sub(start)))
+		r.w.blocked.Add(-1)
+		head = r.w.head.Load()
+	}
+
+	return head, nil
+}
 
-		r.rPos = 1
-		r.cycle += 1
+// readFast attempts to read without taking the writer's lock, which is
+// possible when this reader is the only one registered on the writer. The
+// reader claims its position on the writer so that a concurrent write will
+// wait for it rather than overwrite data being copied. If ok is false, the
+// caller must fall back to the locked path.
+func (r *Reader[T]) readFast(p []T) (n int, ok bool, err error) {
+	w := r.w
+	if r.pinned || r.bounded || r.filter != nil || w.readers.Load() != 1 || w.maxAge.Load() != 0 || w.hasTTL.Load() || w.marks.Load() != nil {
+		return 0, false, nil
+	}
 
-		return r.w.data[0], nil
+	head := w.head.Load()
+	pos := r.pos.Load()
+	if pos >= head {
+		// waiting or checking for close requires the lock
+		return 0, false, nil
 	}
 
-	if r.cycle != r.w.cycle {
-		return empty[T](), errors.New("this should not happen, reader is in the future?")
+	if !w.claim.CompareAndSwap(0, pos+1) {
+		return 0, false, nil
+	}
+	defer w.claim.Store(0)
+	if w.inplace.Load() {
+		// data is being modified, see Writer.exclusive
+		return 0, false, nil
 	}
 
-	// easy
-	if r.rPos >= r.w.wPos {
-		// > shouldn't happen
-		return empty[T](), io.EOF
+	// any data past this point was fully written before our claim
+	head = w.head.Load()
+	if pos < w.pending.Load()-w.size {
+		if r.autoSkip.Load() {
+			// skipping is handled by the locked path
+			return 0, false, nil
+		}
+		r.wentStale(w.pending.Load() - w.size - pos)
+		return 0, true, ErrStaleReader
 	}
+	if tail := w.tail.Load(); pos < tail {
+		// data was discarded by Truncate
+		pos = tail
+		r.pos.Store(pos)
+		if pos >= head {
+			return 0, false, nil
+		}
+	}
+
+	c := min(int64(len(p)), head-pos)
+	w.data.copyOut(p[:c], pos)
+	r.pos.Store(pos + c)
+	return int(c), true, nil
+}
 
-	res := r.w.data[r.rPos]
-	r.rPos += 1
+// ReadOne reads a single element from the ringbuffer. In order to avoid
+// taking the writer's lock for every single element, ReadOne fetches up to
+// the reader's read-ahead amount of elements at once (see SetReadAhead) and
+// serves subsequent calls from that local window until it is exhausted.
+func (r *Reader[T]) ReadOne() (T, error) {
+	if r.isClosed() {
+		// you can't read from a reader after calling Close on it
+		return empty[T](), io.ErrClosedPipe
+	}
+
+	if len(r.ahead) == 0 {
+		if err := r.fill(); err != nil {
+			return empty[T](), err
+		}
+	}
+
+	res := r.ahead[0]
+	r.ahead = r.ahead[1:]
 	return res, nil
 }
 
+// fill fetches up to readAhead elements into the read-ahead window, which
+// must be empty.
+func (r *Reader[T]) fill() error {
+	if size := int(r.w.ceil(int64(r.readAhead))); len(r.aheadBuf) != size {
+		r.aheadBuf = make([]T, size)
+	}
+	n, err := r.read(r.aheadBuf)
+	if n == 0 {
+		return err
+	}
+	r.ahead = r.aheadBuf[:n]
+	return nil
+}
+
 // Close signals this reader will not be used anymore and has finished
 // processing, and should be called after a reader is not useful anymore.
 //
 // If using Writer.Close then calling Close on readers is mandatory, failing
-// to do so will cause a deadlock.
+// to do so will cause a deadlock. Closing a reader more than once has no
+// effect and returns ErrReaderClosed.
 func (r *Reader[T]) Close() error {
 	if atomic.AddUint64(r.closed, 1) != 1 {
-		return nil
+		return ErrReaderClosed
 	}
 
+	r.w.mutex.Lock()
+	delete(r.w.active, r)
+	if r.pinned {
+		r.w.unpin(r)
+	}
+	r.w.checkWatermarks()
+	r.w.mutex.Unlock()
+
+	r.w.readers.Add(-1)
+	r.w.wake()
+	if h := r.w.hooks.Load(); h != nil && h.OnReaderClose != nil {
+		h.OnReaderClose(r.id)
+	}
 	r.w.wg.Done()
 	return nil
 }
 
+// skipStale moves a stale reader to the oldest available data, and returns
+// the number of elements it missed.
+func (r *Reader[T]) skipStale() int64 {
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	oldest := r.w.oldest(r.w.head.Load())
+	pos := r.pos.Load()
+	if pos >= oldest {
+		return 0
+	}
+	missed := oldest - pos
+	r.wentStale(missed)
+	r.addGap(pos, oldest)
+	r.pos.Store(oldest)
+	r.release()
+	return missed
+}
+
+// isClosed returns true if Close has been called on the reader.
+func (r *Reader[T]) isClosed() bool {
+	return atomic.LoadUint64(r.closed) > 0
+}
+
+// Closed returns true if Close was called on the reader.
+func (r *Reader[T]) Closed() bool {
+	return r.isClosed()
+}
+
 // Reset sets the reader's position after the writer's latest write.
 func (r *Reader[T]) Reset() {
 	r.w.mutex.RLock()
 	defer r.w.mutex.RUnlock()
 
-	r.cycle = r.w.cycle
-	r.rPos = r.w.wPos
+	head := r.w.head.Load()
+	if pos, oldest := r.pos.Load(), r.w.oldest(head); pos < oldest {
+		r.addGap(pos, oldest)
+	}
+	r.pos.Store(head)
+	r.ahead = nil
+	r.release()
+}
+
+// Drain moves the reader after all the data currently available without
+// reading it, and returns the number of elements skipped, including elements
+// already overwritten and those fetched by ReadOne but not returned yet.
+func (r *Reader[T]) Drain() (int64, error) {
+	if r.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	head := r.w.head.Load()
+	if r.bounded {
+		head = min(head, r.until)
+	}
+	pos := max(r.pos.Load(), r.w.tail.Load())
+	n := int64(len(r.ahead))
+	if pos < head {
+		n += head - pos
+		r.pos.Store(head)
+		r.release()
+	}
+	r.ahead = nil
+	return n, nil
 }
 
 // SetAutoSkip allows enabling auto skip, when this reader hasn't been reading
 // fast enough and missed some data. This is generally unsafe, but in some
 // cases may be useful to avoid having to handle stale readers.
 func (r *Reader[T]) SetAutoSkip(enabled bool) {
-	r.autoSkip = enabled
+	r.autoSkip.Store(enabled)
+}
+
+// setBlocking sets whether reads block until data is available.
+func (r *Reader[T]) setBlocking(enabled bool) {
+	r.w.mutex.Lock()
+	defer r.w.mutex.Unlock()
+
+	r.block = enabled
+	r.w.broadcast()
+}
+
+// Pin prevents the writer from overwriting data this reader has not read
+// yet: while the reader is pinned, writes wait for it to make room in the
+// buffer, or return ErrBufferFull if the writer's policy is Reject. Other
+// readers are unaffected. It returns ErrStaleReader if the reader already
+// missed data.
+func (r *Reader[T]) Pin() error {
+	if r.isClosed() {
+		return io.ErrClosedPipe
+	}
+
+	r.w.mutex.Lock()
+	defer r.w.mutex.Unlock()
+
+	if r.pos.Load() < r.w.oldest(r.w.head.Load()) {
+		return ErrStaleReader
+	}
+	r.w.pin(r)
+	return nil
+}
+
+// Unpin allows the writer to overwrite data this reader has not read yet,
+// see Pin. Readers of writers using the Block or Reject policy are pinned
+// when created.
+func (r *Reader[T]) Unpin() {
+	r.w.mutex.Lock()
+	defer r.w.mutex.Unlock()
+
+	if r.pinned {
+		r.w.unpin(r)
+	}
+}
+
+// SetStrictEOF sets whether io.EOF is only returned at the end of the
+// stream: once strict EOF is enabled, a non-blocking reader returns ErrNoData
+// when no data is available yet, and io.EOF only once the writer was closed
+// and all its data was read, so consumers can tell when to stop reading.
+func (r *Reader[T]) SetStrictEOF(enabled bool) {
+	r.w.mutex.Lock()
+	defer r.w.mutex.Unlock()
+
+	r.strict = enabled
+}
+
+// SetReadDeadline sets the deadline for blocking reads. Reads which would
+// block past t return os.ErrDeadlineExceeded instead. A zero value for t
+// means reads will not time out. SetReadDeadline can be called while a read
+// is in progress.
+func (r *Reader[T]) SetReadDeadline(t time.Time) {
+	r.w.mutex.Lock()
+	defer r.w.mutex.Unlock()
+
+	r.deadline = t
+	r.w.broadcast()
+}
+
+// readDeadline returns the deadline set by SetReadDeadline.
+func (r *Reader[T]) readDeadline() time.Time {
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	return r.deadline
+}
+
+// SetReadAhead sets the maximum number of elements ReadOne will fetch from
+// the writer at once. Elements fetched this way are considered read as far as
+// the writer is concerned, and will be returned by the following calls to
+// ReadOne or Read even if the writer has since overwritten them. A value of 1
+// disables read-ahead.
+func (r *Reader[T]) SetReadAhead(n int) {
+	if n < 1 {
+		n = 1
+	}
+	r.readAhead = n
 }
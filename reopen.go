@@ -0,0 +1,30 @@
+package ringslice
+
+import "errors"
+
+var ErrReadersOpen = errors.New("cannot reopen a writer which still has open readers")
+
+// Reopen makes a closed writer usable again for a new session, reusing its
+// storage and settings. The elements retained from the previous session are
+// discarded, but positions keep increasing from where they were. Reopen
+// returns ErrReadersOpen if readers of the previous session were not closed
+// yet, and does nothing if the writer is not closed.
+func (w *Writer[T]) Reopen() error {
+	w.mutex.Lock()
+	if !w.closed {
+		w.mutex.Unlock()
+		return nil
+	}
+	if len(w.active) > 0 {
+		w.mutex.Unlock()
+		return ErrReadersOpen
+	}
+	// discard the previous session before any write of the new one can be
+	// accepted
+	w.truncate(0)
+	w.closed = false
+	w.closeErr = nil
+	w.werr = nil
+	w.mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,53 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestReopen(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if err := w.Reopen(); err != nil {
+		t.Errorf("failed open writer reopen test, expected nil, got %v", err)
+	}
+
+	w.Append(1, 2, 3)
+	r := w.BlockingReader()
+	w.closeWithError(nil)
+
+	if err := w.Reopen(); err != ErrReadersOpen {
+		t.Errorf("failed reopen test, expected ErrReadersOpen, got %v", err)
+	}
+	buf := make([]int, 4)
+	r.Read(buf)
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Errorf("failed closed read test, expected io.EOF, got %v", err)
+	}
+	r.Close()
+	w.Close()
+
+	if err := w.Reopen(); err != nil {
+		t.Errorf("failed reopen test, expected nil, got %v", err)
+	}
+	if w.Len() != 0 || w.TotalWritten() != 3 {
+		t.Errorf("failed reopen test, expected empty buffer at 3, got %d at %d", w.Len(), w.TotalWritten())
+	}
+
+	r, err = w.NewReader()
+	if err != nil {
+		t.Errorf("failed reopened reader test, got err=%v", err)
+		return
+	}
+	defer r.Close()
+	w.Append(4, 5)
+	n, _ := r.Read(buf)
+	if !slices.Equal(buf[:n], []int{4, 5}) {
+		t.Errorf("failed reopened read test, expected [4 5], got %v", buf[:n])
+	}
+}
@@ -0,0 +1,30 @@
+package ringslice
+
+import "errors"
+
+var ErrRangeNotRetained = errors.New("requested range is not retained in the buffer")
+
+// Replay returns a new reader delivering exactly the elements at absolute
+// positions [from, to), then io.EOF. It returns ErrRangeNotRetained if part
+// of the range was already discarded or has not been written yet. If the
+// range is overwritten while being replayed, reads return ErrStaleReader.
+func (w *Writer[T]) Replay(from, to int64) (*Reader[T], error) {
+	r, err := w.newReaderErr(false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mutex.RLock()
+	head := w.head.Load()
+	ok := from <= to && from >= w.oldest(head) && to <= head
+	w.mutex.RUnlock()
+	if !ok {
+		r.Close()
+		return nil, ErrRangeNotRetained
+	}
+
+	r.pos.Store(from)
+	r.bounded = true
+	r.until = to
+	return r, nil
+}
@@ -0,0 +1,40 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(0, 1, 2, 3, 4, 5)
+
+	for _, rng := range [][2]int64{{1, 3}, {3, 7}, {4, 3}} {
+		if _, err := w.Replay(rng[0], rng[1]); err != ErrRangeNotRetained {
+			t.Errorf("failed replay range test for %v, expected ErrRangeNotRetained, got %v", rng, err)
+		}
+	}
+
+	r, err := w.Replay(3, 5)
+	if err != nil {
+		t.Errorf("failed replay test, got err=%v", err)
+		return
+	}
+	defer r.Close()
+
+	w.Append(6) // not part of the replay
+
+	buf := make([]int, 8)
+	n, err := r.Read(buf)
+	if err != nil || !slices.Equal(buf[:n], []int{3, 4}) {
+		t.Errorf("failed replay test, expected [3 4], got %v err=%v", buf[:n], err)
+	}
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Errorf("failed replay end test, expected io.EOF, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package ringslice
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaSnapshot is a point-in-time copy of a buffer made by a
+// ReplicaReader.
+type ReplicaSnapshot[T any] struct {
+	Start  int64     // absolute position of the oldest element
+	End    int64     // absolute position following the newest element
+	Time   time.Time // time the snapshot was made at
+	Values []T       // retained elements, from oldest to newest
+}
+
+// ReplicaReader periodically materializes snapshots of a buffer, so
+// analytical consumers can work on a consistent copy of its contents without
+// holding its lock, and follow the elements written after a snapshot with a
+// feed reader while live consumers keep reading the buffer.
+type ReplicaReader[T any] struct {
+	w        *Writer[T]
+	interval time.Duration
+	snap     atomic.Pointer[ReplicaSnapshot[T]]
+
+	mu     sync.Mutex // protects timer and closed, serializes refreshes
+	timer  Timer
+	closed bool
+}
+
+// ReplicaReader returns a ReplicaReader making a first snapshot immediately,
+// then every interval if it is positive.
+func (w *Writer[T]) ReplicaReader(interval time.Duration) *ReplicaReader[T] {
+	rr := &ReplicaReader[T]{w: w, interval: interval}
+	rr.mu.Lock()
+	rr.refresh()
+	rr.arm()
+	rr.mu.Unlock()
+	return rr
+}
+
+// arm schedules the next periodic refresh. The caller must hold mu.
+func (rr *ReplicaReader[T]) arm() {
+	if rr.interval <= 0 || rr.closed {
+		return
+	}
+	rr.timer = rr.w.clk().AfterFunc(rr.interval, func() {
+		rr.mu.Lock()
+		defer rr.mu.Unlock()
+		if !rr.closed {
+			rr.refresh()
+			rr.arm()
+		}
+	})
+}
+
+// refresh makes a new snapshot. The caller must hold mu.
+func (rr *ReplicaReader[T]) refresh() *ReplicaSnapshot[T] {
+	w := rr.w
+	w.mutex.RLock()
+	head := w.head.Load()
+	s := &ReplicaSnapshot[T]{
+		Start:  w.live(head),
+		End:    head,
+		Time:   w.clk().Now(),
+		Values: w.snapshot(),
+	}
+	w.mutex.RUnlock()
+
+	rr.snap.Store(s)
+	return s
+}
+
+// Snapshot returns the latest snapshot. Snapshots are shared and must not be
+// modified.
+func (rr *ReplicaReader[T]) Snapshot() *ReplicaSnapshot[T] {
+	return rr.snap.Load()
+}
+
+// Refresh makes a new snapshot immediately and returns it.
+func (rr *ReplicaReader[T]) Refresh() *ReplicaSnapshot[T] {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	return rr.refresh()
+}
+
+// Feed returns a new reader of the buffer positioned right after the end of
+// s, which reads the elements written since s was made. The reader becomes
+// stale if these elements were overwritten. It returns nil if the writer is
+// closed, see NewFeed.
+func (rr *ReplicaReader[T]) Feed(s *ReplicaSnapshot[T]) *Reader[T] {
+	r, _ := rr.NewFeed(s)
+	return r
+}
+
+// NewFeed is like Feed, but returns ErrWriterClosed instead of a nil reader
+// if the writer is closed.
+func (rr *ReplicaReader[T]) NewFeed(s *ReplicaSnapshot[T]) (*Reader[T], error) {
+	r, err := rr.w.newReaderErr(false, false)
+	if err != nil {
+		return nil, err
+	}
+	r.pos.Store(s.End)
+	return r, nil
+}
+
+// Close stops refreshing snapshots.
+func (rr *ReplicaReader[T]) Close() error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.closed = true
+	if rr.timer != nil {
+		rr.timer.Stop()
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReplicaReader(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	clock := NewManualClock(time.Unix(1000, 0))
+	w.SetClock(clock)
+
+	w.Append(1, 2, 3)
+	rr := w.ReplicaReader(time.Second)
+	defer rr.Close()
+
+	s := rr.Snapshot()
+	if s.Start != 0 || s.End != 3 || !slices.Equal(s.Values, []int{1, 2, 3}) {
+		t.Errorf("failed replica snapshot test, got %+v", s)
+	}
+
+	w.Append(4, 5)
+	if rr.Snapshot() != s {
+		t.Errorf("failed replica snapshot test, snapshot changed before interval")
+	}
+
+	feed := rr.Feed(s)
+	defer feed.Close()
+	buf := make([]int, 8)
+	n, _ := feed.Read(buf)
+	if !slices.Equal(buf[:n], []int{4, 5}) {
+		t.Errorf("failed replica feed test, expected [4 5], got %v", buf[:n])
+	}
+
+	clock.Advance(time.Second)
+	for i := 0; rr.Snapshot() == s && i < 100; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	s = rr.Snapshot()
+	if s.End != 5 || !s.Time.Equal(time.Unix(1001, 0)) {
+		t.Errorf("failed replica refresh test, got %+v", s)
+	}
+
+	w.Append(6)
+	if s = rr.Refresh(); s.End != 6 || len(s.Values) != 6 {
+		t.Errorf("failed replica manual refresh test, got %+v", s)
+	}
+}
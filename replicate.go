@@ -0,0 +1,218 @@
+package ringslice
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var ErrReplicaAhead = errors.New("replica has more data than the source buffer")
+
+const (
+	// replicaMagic starts the handshake sent by ReplicateFrom
+	replicaMagic = "RSR\x01"
+
+	// replicaBatch is the maximum number of elements in a frame
+	replicaBatch = 256
+
+	// maxReplicaElement is the maximum encoded size of an element
+	maxReplicaElement = 64 << 20
+)
+
+// ReplicateTo serves a replica calling ReplicateFrom at the other end of conn,
+// such as a net.Conn, by sending the elements written to the buffer as they
+// arrive. Replication resumes from the replica's position if it is still
+// retained, and from the oldest retained element otherwise, in which case the
+// replica skips the missing elements. Elements are encoded with c, whose name
+// must match the replica's.
+//
+// ReplicateTo returns nil once the buffer is closed, which closes the
+// replica, or once the replica disconnects.
+func (w *Writer[T]) ReplicateTo(conn io.ReadWriter, c Codec[T]) error {
+	in := bufio.NewReader(conn)
+	pos, err := readReplicaHello(in, c)
+	if err != nil {
+		return err
+	}
+
+	r, err := w.NewBlockingReader()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	r.SetAutoSkip(true)
+
+	w.mutex.RLock()
+	head := w.head.Load()
+	w.mutex.RUnlock()
+	if pos > head {
+		return ErrReplicaAhead
+	}
+	r.pos.Store(max(pos, r.pos.Load()))
+
+	// the replica does not send anything after its handshake, stop once
+	// it disconnects
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(io.Discard, in)
+		r.Close()
+		done <- err
+	}()
+
+	out := bufio.NewWriter(conn)
+	buf := make([]T, replicaBatch)
+	var frame []byte
+	for {
+		n, err := r.Read(buf)
+		switch err {
+		case nil:
+		case io.EOF:
+			// buffer closed, send an empty frame
+			frame = binary.AppendUvarint(frame[:0], 0)
+			frame = binary.AppendUvarint(frame, 0)
+			out.Write(frame)
+			return out.Flush()
+		case io.ErrClosedPipe:
+			return <-done
+		default:
+			return err
+		}
+
+		frame = binary.AppendUvarint(frame[:0], uint64(r.pos.Load()-int64(n)))
+		frame = binary.AppendUvarint(frame, uint64(n))
+		for _, v := range buf[:n] {
+			data, err := c.Encode(v)
+			if err != nil {
+				return err
+			}
+			frame = binary.AppendUvarint(frame, uint64(len(data)))
+			frame = append(frame, data...)
+		}
+		out.Write(frame)
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// readReplicaHello reads the handshake sent by ReplicateFrom, and returns the
+// replica's position.
+func readReplicaHello[T any](in stateReader, c Codec[T]) (int64, error) {
+	magic := make([]byte, len(replicaMagic))
+	if _, err := io.ReadFull(in, magic); err != nil {
+		return 0, err
+	}
+	if string(magic) != replicaMagic {
+		return 0, ErrInvalidState
+	}
+
+	l, err := binary.ReadUvarint(in)
+	if err != nil {
+		return 0, err
+	}
+	if l > 1024 {
+		return 0, ErrInvalidState
+	}
+	name := make([]byte, l)
+	if _, err := io.ReadFull(in, name); err != nil {
+		return 0, err
+	}
+	if string(name) != c.Name() {
+		return 0, ErrCodecMismatch
+	}
+
+	pos, err := binary.ReadUvarint(in)
+	return int64(pos), err
+}
+
+// ReplicateFrom makes the buffer a replica of the buffer calling ReplicateTo
+// at the other end of conn, writing the elements it sends at the same
+// positions. If elements are missing, because the replica was disconnected
+// for too long or fell behind, the replica skips them and its readers resume
+// after the gap. The buffer must not be written to by other means.
+//
+// ReplicateFrom returns nil once the source buffer is closed, after closing
+// the buffer without waiting for its readers. Otherwise, replication can be
+// resumed by calling ReplicateFrom again with a new connection.
+func (w *Writer[T]) ReplicateFrom(conn io.ReadWriter, c Codec[T]) error {
+	var hello []byte
+	hello = append(hello, replicaMagic...)
+	hello = binary.AppendUvarint(hello, uint64(len(c.Name())))
+	hello = append(hello, c.Name()...)
+	hello = binary.AppendUvarint(hello, uint64(w.TotalWritten()))
+	if _, err := conn.Write(hello); err != nil {
+		return err
+	}
+
+	in := bufio.NewReader(conn)
+	values := make([]T, 0, replicaBatch)
+	for {
+		seq, err := binary.ReadUvarint(in)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		n, err := binary.ReadUvarint(in)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if n == 0 {
+			w.closeWithError(nil)
+			return nil
+		}
+		if n > replicaBatch {
+			return ErrInvalidState
+		}
+
+		values = values[:0]
+		for i := uint64(0); i < n; i++ {
+			l, err := binary.ReadUvarint(in)
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			if l > maxReplicaElement {
+				return ErrInvalidState
+			}
+			data := make([]byte, l)
+			if _, err := io.ReadFull(in, data); err != nil {
+				return unexpectedEOF(err)
+			}
+			v, err := c.Decode(data)
+			if err != nil {
+				return err
+			}
+			values = append(values, v)
+		}
+
+		if err := w.replicate(int64(seq), values); err != nil {
+			return err
+		}
+	}
+}
+
+// replicate writes values received from a replication source at position
+// pos, skipping any gap before pos and any values already written.
+func (w *Writer[T]) replicate(pos int64, values []T) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	head := w.head.Load()
+	if pos > head {
+		// elements were missed, readers will skip them
+		w.restore(pos, pos)
+		head = pos
+	}
+	if skip := head - pos; skip < int64(len(values)) {
+		_, err := w.writeAll(values[skip:])
+		return err
+	}
+	return nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
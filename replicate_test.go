@@ -0,0 +1,74 @@
+package ringslice
+
+import (
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReplicate(t *testing.T) {
+	src, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	dst, _ := New[int](4)
+	c := JSONCodec[int]{}
+
+	src.Append(1, 2, 3, 4, 5)
+
+	a, b := net.Pipe()
+	srcDone := make(chan error, 1)
+	go func() { srcDone <- src.ReplicateTo(a, c) }()
+	dstDone := make(chan error, 1)
+	go func() { dstDone <- dst.ReplicateFrom(b, c) }()
+
+	waitWritten := func(n int64) {
+		for i := 0; dst.TotalWritten() < n && i < 500; i++ {
+			time.Sleep(2 * time.Millisecond)
+		}
+	}
+
+	waitWritten(5)
+	if s := dst.Snapshot(); !slices.Equal(s, []int{2, 3, 4, 5}) || dst.TotalWritten() != 5 {
+		t.Errorf("failed replication test, expected [2 3 4 5] at 5, got %v at %d", s, dst.TotalWritten())
+	}
+
+	// disconnect, and miss some elements
+	b.Close()
+	if err := <-dstDone; err == nil {
+		t.Errorf("failed replication disconnect test, expected error")
+	}
+	<-srcDone
+	src.Append(6, 7, 8, 9, 10, 11)
+
+	r := dst.Reader()
+	defer r.Close()
+
+	a, b = net.Pipe()
+	go func() { srcDone <- src.ReplicateTo(a, c) }()
+	go func() { dstDone <- dst.ReplicateFrom(b, c) }()
+
+	waitWritten(11)
+	src.Append(12)
+	waitWritten(12)
+	if s := dst.Snapshot(); !slices.Equal(s, []int{9, 10, 11, 12}) || dst.TotalWritten() != 12 {
+		t.Errorf("failed replication gap test, expected [9 10 11 12] at 12, got %v at %d", s, dst.TotalWritten())
+	}
+
+	buf := make([]int, 8)
+	r.SetAutoSkip(true)
+	n, _ := r.Read(buf)
+	if !slices.Equal(buf[:n], []int{9, 10, 11, 12}) {
+		t.Errorf("failed replica reader test, expected [9 10 11 12], got %v", buf[:n])
+	}
+
+	src.Close()
+	if err := <-dstDone; err != nil || !dst.isClosed() {
+		t.Errorf("failed replication close test, got err=%v", err)
+	}
+	if err := <-srcDone; err != nil {
+		t.Errorf("failed replication source close test, got err=%v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package ringhttp provides HTTP handlers exposing the contents of ringslice
+// buffers, for debugging and live streaming.
+//
+//	http.Handle("/debug/events", ringhttp.NewDebugHandler(events, nil))
+package ringhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+// defaultEntries is the number of recent entries shown by default
+const defaultEntries = 100
+
+// DebugHandler is an http.Handler rendering the recent contents of a buffer,
+// its readers and statistics.
+type DebugHandler[T any] struct {
+	w      *ringslice.Writer[T]
+	format func(T) string
+}
+
+// NewDebugHandler returns a DebugHandler for w, formatting elements with
+// format, or fmt.Sprint if format is nil.
+//
+// The response is plain text, or JSON if the request has a format=json query
+// parameter or accepts application/json. The n query parameter sets the
+// maximum number of recent entries to include (100 by default).
+func NewDebugHandler[T any](w *ringslice.Writer[T], format func(T) string) *DebugHandler[T] {
+	if format == nil {
+		format = func(v T) string { return fmt.Sprint(v) }
+	}
+	return &DebugHandler[T]{w: w, format: format}
+}
+
+// debugState is the JSON representation of a buffer served by DebugHandler
+type debugState struct {
+	Size         int64                  `json:"size"`
+	Len          int64                  `json:"len"`
+	TotalWritten int64                  `json:"total_written"`
+	Stats        ringslice.Stats        `json:"stats"`
+	Readers      []ringslice.ReaderInfo `json:"readers"`
+	Entries      []string               `json:"entries"`
+}
+
+func (h *DebugHandler[T]) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	n := defaultEntries
+	if v := req.URL.Query().Get("n"); v != "" {
+		var err error
+		if n, err = strconv.Atoi(v); err != nil || n < 0 {
+			http.Error(rw, "invalid n parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	// no more than the buffer can hold
+	n = int(min(int64(n), h.w.Size()))
+
+	if req.URL.Query().Get("format") == "json" || strings.Contains(req.Header.Get("Accept"), "application/json") {
+		h.serveJSON(rw, n)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	h.w.Dump(rw, n, h.format)
+
+	s := h.w.Stats()
+	fmt.Fprintf(rw, "writes=%d elements=%d wraps=%d overwritten=%d readers=%d blocked=%d\n",
+		s.Writes, s.Elements, s.Wraps, s.Overwritten, s.Readers, s.Blocked)
+}
+
+func (h *DebugHandler[T]) serveJSON(rw http.ResponseWriter, n int) {
+	data := h.w.Snapshot()
+	data = data[len(data)-min(n, len(data)):]
+
+	st := &debugState{
+		Size:         h.w.Size(),
+		Len:          h.w.Len(),
+		TotalWritten: h.w.TotalWritten(),
+		Stats:        h.w.Stats(),
+		Readers:      h.w.Readers(),
+		Entries:      make([]string, len(data)),
+	}
+	for i, v := range data {
+		st.Entries[i] = h.format(v)
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(st)
+}
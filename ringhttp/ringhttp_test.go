@@ -0,0 +1,72 @@
+package ringhttp
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+func TestDebugHandler(t *testing.T) {
+	w, err := ringslice.New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	r := w.Reader()
+	defer r.Close()
+	r.SetName("consumer")
+
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	h := NewDebugHandler(w, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/ring?n=2", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		"data[4:6]: 5 6\n",
+		`reader #1 "consumer": pos=0 lag=6`,
+		"writes=1 elements=6",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("failed debug handler test, expected %q in %q", line, body)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/ring?format=json&n=3", nil))
+	var st debugState
+	if err := json.Unmarshal(rec.Body.Bytes(), &st); err != nil {
+		t.Errorf("failed debug handler json test, got err=%v", err)
+		return
+	}
+	if st.Size != 4 || st.TotalWritten != 6 || len(st.Readers) != 1 || !slices.Equal(st.Entries, []string{"4", "5", "6"}) {
+		t.Errorf("failed debug handler json test, got %+v", st)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/ring?n=x", nil))
+	if rec.Code != 400 {
+		t.Errorf("failed debug handler parameter test, expected 400, got %d", rec.Code)
+	}
+}
+
+func TestDebugHandlerLimit(t *testing.T) {
+	w, err := ringslice.New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	h := NewDebugHandler(w, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/ring?n=1000000000", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "data[2:6]: 3 4 5 6\n") {
+		t.Errorf("failed debug handler limit test, expected the whole buffer in %q", body)
+	}
+}
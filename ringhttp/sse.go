@@ -0,0 +1,85 @@
+package ringhttp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+// sseBatch is the maximum number of elements read at once per connection
+const sseBatch = 64
+
+// SSEHandler is an http.Handler streaming new elements of a buffer to
+// clients as Server-Sent Events.
+type SSEHandler[T any] struct {
+	w      *ringslice.Writer[T]
+	format func(T) string
+}
+
+// NewSSEHandler returns a SSEHandler for w, formatting elements with format,
+// or fmt.Sprint if format is nil. Each connection gets its own reader
+// starting at the buffer's current position, which skips elements that were
+// overwritten if the client is too slow. The reader is closed when the
+// client disconnects or the writer is closed.
+func NewSSEHandler[T any](w *ringslice.Writer[T], format func(T) string) *SSEHandler[T] {
+	if format == nil {
+		format = func(v T) string { return fmt.Sprint(v) }
+	}
+	return &SSEHandler[T]{w: w, format: format}
+}
+
+func (h *SSEHandler[T]) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	r, err := h.w.NewBlockingCurrentReader()
+	if err != nil {
+		http.Error(rw, "buffer is closed", http.StatusServiceUnavailable)
+		return
+	}
+	defer r.Close()
+	r.SetAutoSkip(true)
+	stop := context.AfterFunc(req.Context(), func() { r.Close() })
+	defer stop()
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	bw := bufio.NewWriter(rw)
+	buf := make([]T, sseBatch)
+	for {
+		n, err := r.Read(buf)
+		for _, v := range buf[:n] {
+			writeEvent(bw, h.format(v))
+		}
+		if n > 0 {
+			if bw.Flush() != nil {
+				return
+			}
+			flusher.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes data as a single event, split in several data fields if
+// it spans several lines.
+func writeEvent(bw *bufio.Writer, data string) {
+	for _, line := range strings.Split(data, "\n") {
+		bw.WriteString("data: ")
+		bw.WriteString(strings.TrimSuffix(line, "\r"))
+		bw.WriteByte('\n')
+	}
+	bw.WriteByte('\n')
+}
@@ -0,0 +1,69 @@
+package ringhttp
+
+import (
+	"bufio"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+func TestSSEHandler(t *testing.T) {
+	w, err := ringslice.New[string](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append("old")
+
+	srv := httptest.NewServer(NewSSEHandler(w, nil))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Errorf("failed sse connect test, got err=%v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("failed sse content type test, got %s", ct)
+	}
+
+	w.Append("hello", "two\nlines")
+
+	lines := make(chan string)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+		close(lines)
+	}()
+
+	expect := []string{"data: hello", "", "data: two", "data: lines", ""}
+	for _, e := range expect {
+		select {
+		case l := <-lines:
+			if l != e {
+				t.Errorf("failed sse event test, expected %q, got %q", e, l)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("failed sse event test, timed out")
+			return
+		}
+	}
+
+	// closing the writer ends the stream
+	w.Close()
+	for range lines {
+	}
+
+	// the reader is released on disconnect
+	for i := 0; w.Stats().Readers != 0 && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := w.Stats().Readers; n != 0 {
+		t.Errorf("failed sse cleanup test, expected no readers, got %d", n)
+	}
+}
@@ -0,0 +1,207 @@
+// Package ringprom exposes metrics of ringslice buffers in the Prometheus
+// text exposition format.
+//
+// The package does not depend on the Prometheus client library, and a
+// Handler is not a prometheus.Collector: it is a standalone http.Handler
+// serving its own endpoint, to be scraped as a separate target or mounted
+// next to an existing /metrics endpoint.
+//
+//	h := ringprom.NewHandler()
+//	h.Add("events", eventsRing)
+//	http.Handle("/metrics/rings", h)
+//
+// The following metrics are exported, labelled with ring and, for per-reader
+// metrics, reader (the reader's name, or its ID if it has none):
+//
+//	ringslice_size                         capacity of the ring
+//	ringslice_len                          elements currently retained
+//	ringslice_writes_total                 write calls
+//	ringslice_elements_total               elements written
+//	ringslice_overwritten_total            elements dropped before being read
+//	ringslice_readers                      open readers
+//	ringslice_blocked_readers              readers waiting for data
+//	ringslice_reader_lag                   elements not read yet by a reader
+//	ringslice_reader_blocked_seconds_total time a reader spent waiting for data
+//	ringslice_write_size                   histogram of elements per write call
+//	ringslice_read_size                    histogram of elements per read call
+package ringprom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+// Source is the set of methods a ring must provide to be collected, which
+// any *ringslice.Writer implements.
+type Source interface {
+	Size() int64
+	Len() int64
+	Stats() ringslice.Stats
+	Readers() []ringslice.ReaderInfo
+}
+
+// Handler serves metrics of a set of named rings.
+type Handler struct {
+	mu    sync.Mutex
+	rings map[string]Source
+}
+
+// NewHandler returns a new Handler without rings.
+func NewHandler() *Handler {
+	return &Handler{rings: make(map[string]Source)}
+}
+
+// Add registers a ring under the given name, replacing any ring previously
+// registered with the same name.
+func (c *Handler) Add(name string, src Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rings[name] = src
+}
+
+// Remove unregisters the ring with the given name.
+func (c *Handler) Remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.rings, name)
+}
+
+// metric describes an exported metric
+type metric struct {
+	name, typ, help string
+}
+
+var (
+	mSize      = metric{"ringslice_size", "gauge", "Capacity of the ring."}
+	mLen       = metric{"ringslice_len", "gauge", "Number of elements currently retained."}
+	mWrites    = metric{"ringslice_writes_total", "counter", "Number of write calls."}
+	mElements  = metric{"ringslice_elements_total", "counter", "Number of elements written."}
+	mOverwrite = metric{"ringslice_overwritten_total", "counter", "Elements overwritten before being read by the slowest reader."}
+	mReaders   = metric{"ringslice_readers", "gauge", "Number of open readers."}
+	mBlocked   = metric{"ringslice_blocked_readers", "gauge", "Number of readers waiting for data."}
+	mLag       = metric{"ringslice_reader_lag", "gauge", "Number of elements not read yet by the reader."}
+	mWaited    = metric{"ringslice_reader_blocked_seconds_total", "counter", "Total time the reader spent waiting for data."}
+	mWSize     = metric{"ringslice_write_size", "histogram", "Number of elements per write call."}
+	mRSize     = metric{"ringslice_read_size", "histogram", "Number of elements per read call."}
+)
+
+// sample is a single value of a metric
+type sample struct {
+	ring, reader string
+	value        float64
+	hist         *ringslice.SizeHistogram
+}
+
+// WriteTo writes the current metrics of all registered rings to w in the
+// Prometheus text exposition format.
+func (c *Handler) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	names := make([]string, 0, len(c.rings))
+	for name := range c.rings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	srcs := make([]Source, len(names))
+	for i, name := range names {
+		srcs[i] = c.rings[name]
+	}
+	c.mu.Unlock()
+
+	samples := make(map[metric][]sample)
+	for i, src := range srcs {
+		ring := names[i]
+		st := src.Stats()
+		samples[mSize] = append(samples[mSize], sample{ring: ring, value: float64(src.Size())})
+		samples[mLen] = append(samples[mLen], sample{ring: ring, value: float64(src.Len())})
+		samples[mWrites] = append(samples[mWrites], sample{ring: ring, value: float64(st.Writes)})
+		samples[mElements] = append(samples[mElements], sample{ring: ring, value: float64(st.Elements)})
+		samples[mOverwrite] = append(samples[mOverwrite], sample{ring: ring, value: float64(st.Overwritten)})
+		samples[mReaders] = append(samples[mReaders], sample{ring: ring, value: float64(st.Readers)})
+		samples[mBlocked] = append(samples[mBlocked], sample{ring: ring, value: float64(st.Blocked)})
+		samples[mWSize] = append(samples[mWSize], sample{ring: ring, hist: &st.WriteSizes})
+		samples[mRSize] = append(samples[mRSize], sample{ring: ring, hist: &st.ReadSizes})
+		for _, r := range src.Readers() {
+			samples[mLag] = append(samples[mLag], sample{ring: ring, reader: r.Label(), value: float64(r.Lag)})
+			samples[mWaited] = append(samples[mWaited], sample{ring: ring, reader: r.Label(), value: r.Blocked.Seconds()})
+		}
+	}
+
+	cw := &countWriter{w: w}
+	out := bufio.NewWriter(cw)
+	for _, m := range []metric{mSize, mLen, mWrites, mElements, mOverwrite, mReaders, mBlocked, mLag, mWaited, mWSize, mRSize} {
+		if len(samples[m]) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.typ)
+		for _, s := range samples[m] {
+			if s.hist != nil {
+				writeHistogram(out, m.name, s.ring, s.hist)
+				continue
+			}
+			if s.reader != "" {
+				fmt.Fprintf(out, "%s{ring=\"%s\",reader=\"%s\"} %g\n", m.name, escape(s.ring), escape(s.reader), s.value)
+			} else {
+				fmt.Fprintf(out, "%s{ring=\"%s\"} %g\n", m.name, escape(s.ring), s.value)
+			}
+		}
+	}
+	err := out.Flush()
+	return cw.n, err
+}
+
+// writeHistogram writes the cumulative buckets of h, omitting empty buckets
+// past the largest size observed. The last bucket, which has no finite upper
+// bound, is only counted in +Inf.
+func writeHistogram(out io.Writer, name, ring string, h *ringslice.SizeHistogram) {
+	ring = escape(ring)
+	last := 0
+	for i, v := range h.Buckets[:len(h.Buckets)-1] {
+		if v > 0 {
+			last = i
+		}
+	}
+
+	var count int64
+	for i := 0; i <= last; i++ {
+		count += h.Buckets[i]
+		fmt.Fprintf(out, "%s_bucket{ring=\"%s\",le=\"%d\"} %d\n", name, ring, h.UpperBound(i), count)
+	}
+	fmt.Fprintf(out, "%s_bucket{ring=\"%s\",le=\"+Inf\"} %d\n", name, ring, h.Count())
+	fmt.Fprintf(out, "%s_sum{ring=\"%s\"} %d\n", name, ring, h.Sum)
+	fmt.Fprintf(out, "%s_count{ring=\"%s\"} %d\n", name, ring, h.Count())
+}
+
+// ServeHTTP implements http.Handler, serving the metrics of all registered
+// rings.
+func (c *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(rw)
+}
+
+// labelEscaper escapes label values as required by the text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// countWriter counts bytes written to w
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,45 @@
+package ringprom
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+func TestHandler(t *testing.T) {
+	w, err := ringslice.New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	r := w.Reader()
+	defer r.Close()
+	r.SetName(`a "quoted" reader`)
+
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	c := NewHandler()
+	c.Add(`"events"`, w)
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, line := range []string{
+		"# TYPE ringslice_size gauge",
+		`ringslice_size{ring="\"events\""} 4`,
+		`ringslice_elements_total{ring="\"events\""} 6`,
+		`ringslice_overwritten_total{ring="\"events\""} 2`,
+		`ringslice_reader_lag{ring="\"events\"",reader="a \"quoted\" reader"} 6`,
+		"# TYPE ringslice_write_size histogram",
+		`ringslice_write_size_bucket{ring="\"events\"",le="7"} 1`,
+		`ringslice_write_size_sum{ring="\"events\""} 6`,
+		`ringslice_write_size_count{ring="\"events\""} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("failed handler test, expected line %q in:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+// Package ringslicetest provides a harness to reproduce interleavings of
+// ringslice producers and consumers deterministically in tests.
+//
+// A Sim owns a Writer using a ManualClock and a set of named readers, and
+// runs scripted steps one after the other. Blocking reads are started in the
+// background with Start, which only returns once the read either completed
+// or is actually blocked waiting for data, so the following steps always
+// happen while the reader is blocked:
+//
+//	sim, _ := ringslicetest.New[int](4)
+//	defer sim.Close()
+//	err := sim.Run(
+//		ringslicetest.Open[int]("r", true),
+//		ringslicetest.Start[int]("r", 4),
+//		ringslicetest.CloseWriter[int](),
+//		ringslicetest.ExpectWait[int]("r", nil, io.EOF),
+//	)
+package ringslicetest
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+// Epoch is the initial time of the clock of a Sim.
+var Epoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Timeout is the real time a step waits for a reader before failing, so a
+// broken script does not hang the test suite.
+var Timeout = 10 * time.Second
+
+// Step is a single scripted action of a Sim.
+type Step[T any] func(s *Sim[T]) error
+
+// Sim runs scripted steps against a Writer.
+type Sim[T any] struct {
+	W     *ringslice.Writer[T]
+	Clock *ringslice.ManualClock
+
+	readers map[string]*reader[T]
+	closed  chan struct{} // closed once the writer's Close returned
+}
+
+// reader is a named reader of a Sim, and its read in progress if any.
+type reader[T any] struct {
+	r    *ringslice.Reader[T]
+	done chan result[T]
+}
+
+type result[T any] struct {
+	values []T
+	err    error
+}
+
+// New returns a Sim with a Writer of the given size, using a ManualClock set
+// to Epoch. The options are applied before the clock is set.
+func New[T any](size int64, opts ...ringslice.Option) (*Sim[T], error) {
+	clock := ringslice.NewManualClock(Epoch)
+	w, err := ringslice.New[T](size, append(opts, ringslice.WithClock(clock))...)
+	if err != nil {
+		return nil, err
+	}
+	return &Sim[T]{W: w, Clock: clock, readers: make(map[string]*reader[T])}, nil
+}
+
+// Run runs steps in order, and returns the error of the first failing step.
+func (s *Sim[T]) Run(steps ...Step[T]) error {
+	for i, step := range steps {
+		if err := step(s); err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Reader returns the reader with the given name, or nil if there is none.
+func (s *Sim[T]) Reader(name string) *ringslice.Reader[T] {
+	if sr, ok := s.readers[name]; ok {
+		return sr.r
+	}
+	return nil
+}
+
+// Close closes all readers and the writer, and waits for reads in progress
+// to return.
+func (s *Sim[T]) Close() error {
+	for _, sr := range s.readers {
+		sr.r.Close()
+		if sr.done != nil {
+			<-sr.done
+		}
+	}
+	if s.closed == nil {
+		return s.W.Close()
+	}
+	<-s.closed
+	return nil
+}
+
+// get returns the reader with the given name.
+func (s *Sim[T]) get(name string) (*reader[T], error) {
+	sr, ok := s.readers[name]
+	if !ok {
+		return nil, fmt.Errorf("no reader named %q", name)
+	}
+	return sr, nil
+}
+
+// Open opens a reader at the oldest available position. Readers which are
+// not blocking follow the writer's WithBlocking option.
+func Open[T any](name string, blocking bool) Step[T] {
+	return func(s *Sim[T]) error {
+		if _, ok := s.readers[name]; ok {
+			return fmt.Errorf("reader %q already exists", name)
+		}
+		newReader := s.W.NewReader
+		if blocking {
+			newReader = s.W.NewBlockingReader
+		}
+		r, err := newReader()
+		if err != nil {
+			return err
+		}
+		r.SetName(name)
+		s.readers[name] = &reader[T]{r: r}
+		return nil
+	}
+}
+
+// CloseReader closes a reader. A read in progress returns.
+func CloseReader[T any](name string) Step[T] {
+	return func(s *Sim[T]) error {
+		sr, err := s.get(name)
+		if err != nil {
+			return err
+		}
+		sr.r.Close()
+		if sr.done != nil {
+			<-sr.done
+		}
+		delete(s.readers, name)
+		return nil
+	}
+}
+
+// Write writes values to the writer.
+func Write[T any](values ...T) Step[T] {
+	return func(s *Sim[T]) error {
+		_, err := s.W.Write(values)
+		return err
+	}
+}
+
+// Advance moves the clock forward by d.
+func Advance[T any](d time.Duration) Step[T] {
+	return func(s *Sim[T]) error {
+		s.Clock.Advance(d)
+		return nil
+	}
+}
+
+// CloseWriter closes the writer. Since closing waits for all readers to be
+// closed, the close completes in the background.
+func CloseWriter[T any]() Step[T] {
+	return func(s *Sim[T]) error {
+		if s.closed != nil {
+			return errors.New("writer already closed")
+		}
+		s.closed = make(chan struct{})
+		go func() {
+			s.W.Close()
+			close(s.closed)
+		}()
+		// wait for the close to be visible to readers
+		return poll(func() bool { return s.W.Closed() })
+	}
+}
+
+// Read reads up to n elements from a reader, and passes the result to check.
+// The reader must not have a read in progress.
+func Read[T any](name string, n int, check func(values []T, err error) error) Step[T] {
+	return func(s *Sim[T]) error {
+		sr, err := s.get(name)
+		if err != nil {
+			return err
+		}
+		if sr.done != nil {
+			return fmt.Errorf("reader %q has a read in progress", name)
+		}
+		buf := make([]T, n)
+		n, err := sr.r.Read(buf)
+		return check(buf[:n], err)
+	}
+}
+
+// Start starts reading up to n elements from a reader in the background, and
+// returns once the read either completed or is blocked waiting for data. Use
+// Wait to get the result.
+func Start[T any](name string, n int) Step[T] {
+	return func(s *Sim[T]) error {
+		sr, err := s.get(name)
+		if err != nil {
+			return err
+		}
+		if sr.done != nil {
+			return fmt.Errorf("reader %q has a read in progress", name)
+		}
+		blocked := s.W.Stats().Blocked
+		done := make(chan result[T], 1)
+		sr.done = done
+		go func() {
+			buf := make([]T, n)
+			n, err := sr.r.Read(buf)
+			done <- result[T]{buf[:n], err}
+		}()
+		return poll(func() bool {
+			return len(done) > 0 || s.W.Stats().Blocked > blocked
+		})
+	}
+}
+
+// Wait waits for the read started on a reader by Start to complete, and
+// passes its result to check.
+func Wait[T any](name string, check func(values []T, err error) error) Step[T] {
+	return func(s *Sim[T]) error {
+		sr, err := s.get(name)
+		if err != nil {
+			return err
+		}
+		if sr.done == nil {
+			return fmt.Errorf("reader %q has no read in progress", name)
+		}
+		select {
+		case res := <-sr.done:
+			sr.done = nil
+			return check(res.values, res.err)
+		case <-time.After(Timeout):
+			return fmt.Errorf("reader %q: read did not complete", name)
+		}
+	}
+}
+
+// Expect returns a check function for Read and Wait, which expects the given
+// values and an error matching err with errors.Is.
+func Expect[T comparable](values []T, err error) func([]T, error) error {
+	return func(got []T, gotErr error) error {
+		if !errors.Is(gotErr, err) {
+			return fmt.Errorf("expected error %v, got %v", err, gotErr)
+		}
+		if !slices.Equal(got, values) {
+			return fmt.Errorf("expected %v, got %v", values, got)
+		}
+		return nil
+	}
+}
+
+// ExpectRead is Read with an Expect check.
+func ExpectRead[T comparable](name string, values []T, err error) Step[T] {
+	return Read(name, max(len(values), 1), Expect(values, err))
+}
+
+// ExpectWait is Wait with an Expect check.
+func ExpectWait[T comparable](name string, values []T, err error) Step[T] {
+	return Wait(name, Expect(values, err))
+}
+
+// Do runs f as a step, for actions not covered by the package.
+func Do[T any](f func(s *Sim[T]) error) Step[T] {
+	return f
+}
+
+// poll waits for cond to become true, yielding to other goroutines.
+func poll(cond func() bool) error {
+	deadline := time.Now().Add(Timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return errors.New("timed out")
+		}
+		runtime.Gosched()
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package ringslicetest
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+func TestStaleAtWrap(t *testing.T) {
+	sim, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize sim")
+		return
+	}
+	defer sim.Close()
+
+	err = sim.Run(
+		Open[int]("r", false),
+		Write(1, 2, 3, 4),
+		ExpectRead("r", []int{1, 2}, nil),
+		Write(5, 6),
+		ExpectRead("r", []int{3, 4, 5, 6}, nil),
+		Write(7, 8, 9, 10, 11),
+		ExpectRead[int]("r", nil, ringslice.ErrStaleReader),
+	)
+	if err != nil {
+		t.Errorf("failed stale reader test: %s", err)
+	}
+}
+
+func TestCloseDuringBlockedRead(t *testing.T) {
+	sim, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize sim")
+		return
+	}
+	defer sim.Close()
+
+	err = sim.Run(
+		Open[int]("r", true),
+		Write(1),
+		ExpectRead("r", []int{1}, nil),
+		Start[int]("r", 4),
+		CloseWriter[int](),
+		ExpectWait[int]("r", nil, io.EOF),
+	)
+	if err != nil {
+		t.Errorf("failed close during blocked read test: %s", err)
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	sim, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize sim")
+		return
+	}
+	defer sim.Close()
+
+	err = sim.Run(
+		Open[int]("r", true),
+		Do(func(s *Sim[int]) error {
+			s.Reader("r").SetReadDeadline(s.Clock.Now().Add(time.Second))
+			return nil
+		}),
+		Start[int]("r", 4),
+		Advance[int](time.Second),
+		ExpectWait[int]("r", nil, os.ErrDeadlineExceeded),
+	)
+	if err != nil {
+		t.Errorf("failed deadline test: %s", err)
+	}
+}
+
+func TestFailingStep(t *testing.T) {
+	sim, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize sim")
+		return
+	}
+	defer sim.Close()
+
+	if err := sim.Run(ExpectRead("missing", []int{1}, nil)); err == nil {
+		t.Errorf("failed failing step test, expected an error")
+	}
+}
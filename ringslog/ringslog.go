@@ -0,0 +1,127 @@
+// Package ringslog provides a slog.Handler that keeps the most recent log
+// records in a ringslice buffer, so they can be exposed on a debug endpoint
+// without writing them to files.
+//
+//	h, _ := ringslog.NewHandler(1000, nil)
+//	logger := slog.New(h)
+//	http.Handle("/debug/logs", h)
+package ringslog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/KarpelesLab/ringslice"
+)
+
+// Entry is a log record kept by a Handler. Attributes added with WithAttrs
+// and WithGroup are included in Attrs.
+type Entry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   []slog.Attr
+}
+
+// Handler is a slog.Handler storing records in a ring buffer. Handlers
+// derived with WithAttrs and WithGroup share the same buffer.
+type Handler struct {
+	ring  *ringslice.Writer[Entry]
+	level slog.Leveler
+	wrap  func([]slog.Attr) []slog.Attr // applies attributes and groups
+}
+
+// NewHandler returns a Handler keeping the last size records. Only the Level
+// of opts is used, and records below slog.LevelInfo are discarded if opts
+// or its Level is nil.
+func NewHandler(size int64, opts *slog.HandlerOptions) (*Handler, error) {
+	ring, err := ringslice.New[Entry](size)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{ring: ring, level: slog.LevelInfo}
+	if opts != nil && opts.Level != nil {
+		h.level = opts.Level
+	}
+	h.wrap = func(attrs []slog.Attr) []slog.Attr { return attrs }
+	return h, nil
+}
+
+// Ring returns the buffer records are stored in, for instance to follow new
+// records with a blocking reader.
+func (h *Handler) Ring() *ringslice.Writer[Entry] {
+	return h.ring
+}
+
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		a.Value = a.Value.Resolve()
+		attrs = append(attrs, a)
+		return true
+	})
+
+	_, err := h.ring.Append(Entry{Time: r.Time, Level: r.Level, Message: r.Message, Attrs: h.wrap(attrs)})
+	return err
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	attrs = slices.Clip(attrs)
+	wrap := h.wrap
+	res := *h
+	res.wrap = func(a []slog.Attr) []slog.Attr { return wrap(append(attrs, a...)) }
+	return &res
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	wrap := h.wrap
+	res := *h
+	res.wrap = func(a []slog.Attr) []slog.Attr {
+		if len(a) == 0 {
+			// empty groups are omitted
+			return wrap(nil)
+		}
+		return wrap([]slog.Attr{{Key: name, Value: slog.GroupValue(a...)}})
+	}
+	return &res
+}
+
+// Records returns a copy of the retained records, from oldest to newest.
+func (h *Handler) Records() []Entry {
+	return h.ring.Snapshot()
+}
+
+// Dump writes the retained records to out in the format of
+// slog.TextHandler, one per line.
+func (h *Handler) Dump(out io.Writer) error {
+	text := slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.Level(-128)})
+	for _, e := range h.Records() {
+		r := slog.NewRecord(e.Time, e.Level, e.Message, 0)
+		r.AddAttrs(e.Attrs...)
+		if err := text.Handle(context.Background(), r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeHTTP serves the retained records as plain text, as written by Dump.
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	h.Dump(rw)
+}
@@ -0,0 +1,48 @@
+package ringslog
+
+import (
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	h, err := NewHandler(3, nil)
+	if err != nil {
+		t.Errorf("failed to initialize handler")
+		return
+	}
+
+	logger := slog.New(h)
+	logger.Debug("hidden")
+	logger.Info("first")
+	logger.With("req", 42).WithGroup("g").Warn("second", "a", 1)
+	logger.WithGroup("empty").Info("third")
+	logger.Error("fourth", slog.Group("err", "code", 7))
+
+	res := h.Records()
+	if len(res) != 3 {
+		t.Errorf("failed handler test, expected 3 records, got %d", len(res))
+		return
+	}
+	if res[0].Message != "second" || res[0].Level != slog.LevelWarn || len(res[0].Attrs) != 2 {
+		t.Errorf("failed handler test, got %+v", res[0])
+	}
+	if len(res[1].Attrs) != 0 {
+		t.Errorf("failed empty group test, got %+v", res[1].Attrs)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/logs", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		"level=WARN msg=second req=42 g.a=1\n",
+		"level=INFO msg=third\n",
+		"level=ERROR msg=fourth err.code=7\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("failed dump test, expected %q in %q", line, body)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package ringslice
+
+import (
+	"io"
+	"time"
+)
+
+// SamplingReader reads at most one element per interval from a buffer: the
+// newest element written when the interval has elapsed. It is meant to drive
+// low frequency consumers, such as displays, from high rate buffers.
+type SamplingReader[T any] struct {
+	r        *Reader[T]
+	interval time.Duration
+	next     time.Time // earliest time of the next sample
+}
+
+// SamplingReader returns a new reader sampling the buffer every interval.
+// Reads block until the interval since the previous sample has elapsed, and
+// then until at least one new element is available. It returns nil if the
+// writer is closed, see NewSamplingReader.
+func (w *Writer[T]) SamplingReader(interval time.Duration) *SamplingReader[T] {
+	s, _ := w.NewSamplingReader(interval)
+	return s
+}
+
+// NewSamplingReader is like SamplingReader, but returns ErrWriterClosed
+// instead of a nil reader if the writer is closed.
+func (w *Writer[T]) NewSamplingReader(interval time.Duration) (*SamplingReader[T], error) {
+	r, err := w.newReaderErr(true, false)
+	if err != nil {
+		return nil, err
+	}
+	return &SamplingReader[T]{r: r, interval: interval}, nil
+}
+
+// Read returns the newest element of the buffer, once the interval since the
+// previous sample has elapsed and new data has been written. Elements written
+// in between are skipped. Read returns io.EOF once the writer is closed and no
+// new element is available.
+func (s *SamplingReader[T]) Read() (T, error) {
+	r := s.r
+	if r.isClosed() {
+		return empty[T](), io.ErrClosedPipe
+	}
+
+	clock := r.w.clk()
+	if d := s.next.Sub(clock.Now()); d > 0 {
+		sleep(clock, d)
+	}
+
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	head, err := r.wait()
+	if err != nil {
+		return empty[T](), err
+	}
+	if r.pos.Load() >= head || head == r.w.oldest(head) {
+		return empty[T](), r.eof()
+	}
+
+	r.pos.Store(head)
+	r.release()
+	s.next = clock.Now().Add(s.interval)
+	return r.w.data.at(head - 1), nil
+}
+
+// Close closes the underlying reader, see Reader.Close.
+func (s *SamplingReader[T]) Close() error {
+	return s.r.Close()
+}
@@ -0,0 +1,48 @@
+package ringslice
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func TestSamplingReader(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.SetClock(clock)
+
+	s := w.SamplingReader(time.Second)
+	w.Append(1, 2, 3)
+	if v, err := s.Read(); v != 3 || err != nil {
+		t.Errorf("failed sampling reader test, expected 3, got %d err=%v", v, err)
+	}
+
+	w.Append(4, 5)
+	res := make(chan int)
+	go func() {
+		v, _ := s.Read()
+		res <- v
+	}()
+
+	select {
+	case v := <-res:
+		t.Errorf("failed sampling reader interval test, read %d before interval", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+	w.Append(6)
+	clock.Advance(time.Second)
+	if v := <-res; v != 6 {
+		t.Errorf("failed sampling reader test, expected 6, got %d", v)
+	}
+
+	clock.Advance(time.Second)
+	go w.Close()
+	if _, err := s.Read(); err != io.EOF {
+		t.Errorf("failed sampling reader close test, expected io.EOF, got %v", err)
+	}
+	s.Close()
+}
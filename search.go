@@ -0,0 +1,46 @@
+package ringslice
+
+// IndexOf returns the absolute position of the oldest element retained in w
+// equal to v, or -1 if there is none. Elements are compared in place while w
+// is locked for reading.
+func IndexOf[T comparable](w *Writer[T], v T) int64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	res := int64(-1)
+	w.each(0, func(pos int64, e T) bool {
+		if e == v {
+			res = pos
+			return false
+		}
+		return true
+	})
+	return res
+}
+
+// Contains returns true if an element equal to v is retained in w.
+func Contains[T comparable](w *Writer[T], v T) bool {
+	return IndexOf(w, v) >= 0
+}
+
+// SearchFunc uses binary search to find the absolute position of the oldest
+// element retained in the buffer for which f returns true, assuming f
+// returns false then true over the elements in write order (for example if
+// elements are increasing). It returns TotalWritten() if there is no such
+// element. Expired elements (see WriteTTL) are not skipped.
+func (w *Writer[T]) SearchFunc(f func(T) bool) int64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head := w.head.Load()
+	lo, hi := w.live(head), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if f(w.data.at(mid)) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
@@ -0,0 +1,46 @@
+package ringslice
+
+import "testing"
+
+func TestIndexOf(t *testing.T) {
+	w, err := New[string](3)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append("a", "b", "c", "b", "d")
+
+	if i := IndexOf(w, "b"); i != 3 {
+		t.Errorf("failed index test, expected 3, got %d", i)
+	}
+	if i := IndexOf(w, "a"); i != -1 {
+		t.Errorf("failed overwritten index test, expected -1, got %d", i)
+	}
+	if !Contains(w, "c") || Contains(w, "e") {
+		t.Errorf("failed contains test")
+	}
+}
+
+func TestSearchFunc(t *testing.T) {
+	w, err := New[int](5)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if p := w.SearchFunc(func(v int) bool { return v >= 0 }); p != 0 {
+		t.Errorf("failed empty search test, expected 0, got %d", p)
+	}
+
+	for i := 0; i < 8; i++ {
+		w.Append(i * 10)
+	}
+
+	tests := map[int]int64{0: 3, 35: 4, 40: 4, 70: 7, 71: 8}
+	for v, expect := range tests {
+		if p := w.SearchFunc(func(e int) bool { return e >= v }); p != expect {
+			t.Errorf("failed search test for %d, expected %d, got %d", v, expect, p)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+//go:build linux || darwin || freebsd
+
+package ringslice
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const (
+	// shmMagic identifies a shared ring file
+	shmMagic = "RSSHM\x00\x00\x01"
+
+	// shmHeaderSize is the size of the shared ring header, data follows
+	shmHeaderSize = 64
+
+	// defaultPollInterval is how often blocking shared readers check for
+	// new data
+	defaultPollInterval = time.Millisecond
+)
+
+// shmHeader is the layout of the beginning of a shared ring file. pending
+// is the head position a write in progress will reach, allowing readers to
+// detect data overwritten while they were copying it. closed is set once the
+// writer is closed.
+type shmHeader struct {
+	magic    [8]byte
+	elemSize uint64
+	size     uint64
+	head     atomic.Int64
+	pending  atomic.Int64
+	closed   atomic.Bool
+}
+
+// shmRing is a mapping of a shared ring file
+type shmRing[T any] struct {
+	mem  []byte
+	hdr  *shmHeader
+	data []T
+	size int64
+}
+
+// SharedWriter writes to a ring buffer stored in a memory-mapped file, which
+// can be read by other processes using OpenShared. Only one SharedWriter
+// should exist for a given file. Element types must have a fixed size and
+// contain no pointers.
+type SharedWriter[T any] struct {
+	ring *shmRing[T] // nil once closed
+	mu   sync.Mutex
+}
+
+// SharedReader reads from a ring buffer stored in a memory-mapped file, see
+// SharedWriter. Since no notification is available across processes,
+// blocking readers poll for new data.
+type SharedReader[T any] struct {
+	ring     *shmRing[T] // nil once closed
+	mu       sync.Mutex  // protects ring against Close
+	pos      int64
+	block    bool
+	autoSkip bool
+	poll     time.Duration
+}
+
+// CreateShared creates (or overwrites) the file at path as a shared ring of
+// the given size, and returns a writer for it. On Linux, placing the file in
+// /dev/shm keeps it in memory.
+func CreateShared[T any](path string, size int64) (*SharedWriter[T], error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+	elemSize := int64(unsafe.Sizeof(empty[T]()))
+	if !isPlain(reflect.TypeFor[T]()) || elemSize == 0 {
+		return nil, ErrNotPlain
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := shmLen(size, elemSize)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.Truncate(l); err != nil {
+		return nil, err
+	}
+
+	ring, err := mapShared[T](f, size)
+	if err != nil {
+		return nil, err
+	}
+	ring.hdr.elemSize = uint64(elemSize)
+	ring.hdr.size = uint64(size)
+	copy(ring.hdr.magic[:], shmMagic)
+
+	return &SharedWriter[T]{ring: ring}, nil
+}
+
+// OpenShared opens the shared ring at path created by CreateShared, and
+// returns a reader positioned at the oldest available data.
+func OpenShared[T any](path string) (*SharedReader[T], error) {
+	elemSize := int64(unsafe.Sizeof(empty[T]()))
+	if !isPlain(reflect.TypeFor[T]()) || elemSize == 0 {
+		return nil, ErrNotPlain
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var hdr [24]byte
+	if _, err = io.ReadFull(f, hdr[:]); err != nil {
+		return nil, err
+	}
+	if string(hdr[:8]) != shmMagic {
+		return nil, ErrInvalidState
+	}
+	if binary.NativeEndian.Uint64(hdr[8:]) != uint64(elemSize) {
+		return nil, ErrInvalidState
+	}
+
+	// the file must hold the whole ring, as accessing the mapping past its
+	// end would fault
+	size := binary.NativeEndian.Uint64(hdr[16:])
+	if size == 0 || size > math.MaxInt64 {
+		return nil, ErrInvalidState
+	}
+	l, err := shmLen(int64(size), elemSize)
+	if err != nil {
+		return nil, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if st.Size() < l {
+		return nil, ErrInvalidState
+	}
+
+	ring, err := mapShared[T](f, int64(size))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharedReader[T]{
+		ring: ring,
+		pos:  max(ring.hdr.head.Load()-ring.size, 0),
+		poll: defaultPollInterval,
+	}, nil
+}
+
+// shmLen returns the length of the file of a shared ring of size elements of
+// elemSize bytes, or ErrInvalidSize if it overflows.
+func shmLen(size, elemSize int64) (int64, error) {
+	if size > (math.MaxInt-shmHeaderSize)/elemSize {
+		return 0, ErrInvalidSize
+	}
+	return shmHeaderSize + size*elemSize, nil
+}
+
+func mapShared[T any](f *os.File, size int64) (*shmRing[T], error) {
+	l, err := shmLen(size, int64(unsafe.Sizeof(empty[T]())))
+	if err != nil {
+		return nil, err
+	}
+	mem, err := syscall.Mmap(int(f.Fd()), 0, int(l), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shmRing[T]{
+		mem:  mem,
+		hdr:  (*shmHeader)(unsafe.Pointer(&mem[0])),
+		data: unsafe.Slice((*T)(unsafe.Pointer(&mem[shmHeaderSize])), size),
+		size: size,
+	}, nil
+}
+
+// close unmaps the ring, which must not be used afterwards.
+func (s *shmRing[T]) close() error {
+	err := syscall.Munmap(s.mem)
+	s.mem, s.hdr, s.data = nil, nil, nil
+	return err
+}
+
+// Append values to the shared ring.
+func (w *SharedWriter[T]) Append(values ...T) (int, error) {
+	return w.Write(values)
+}
+
+// Write writes values to the shared ring. It returns io.ErrClosedPipe if the
+// writer was closed.
+func (w *SharedWriter[T]) Write(values []T) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s := w.ring
+	if s == nil {
+		return 0, io.ErrClosedPipe
+	}
+	n := int64(len(values))
+	head := s.hdr.head.Load()
+
+	if n > s.size {
+		head += n - s.size
+		values = values[n-s.size:]
+	}
+
+	end := head + int64(len(values))
+	s.hdr.pending.Store(end)
+
+	off := head % s.size
+	c := copy(s.data[off:], values)
+	copy(s.data, values[c:])
+
+	s.hdr.head.Store(end)
+	return int(n), nil
+}
+
+// TotalWritten returns the total number of elements ever written, or zero
+// if the writer was closed.
+func (w *SharedWriter[T]) TotalWritten() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ring == nil {
+		return 0
+	}
+	return w.ring.hdr.head.Load()
+}
+
+// Close marks the shared ring as closed, so readers return io.EOF once they
+// read all data, and unmaps it. The file is left in place for readers.
+// Closing more than once returns io.ErrClosedPipe.
+func (w *SharedWriter[T]) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ring == nil {
+		return io.ErrClosedPipe
+	}
+	w.ring.hdr.closed.Store(true)
+	err := w.ring.close()
+	w.ring = nil
+	return err
+}
+
+// SetBlocking makes reads wait for data to become available, checking
+// every poll interval (1ms if zero).
+func (r *SharedReader[T]) SetBlocking(enabled bool, poll time.Duration) {
+	if poll <= 0 {
+		poll = defaultPollInterval
+	}
+	r.block = enabled
+	r.poll = poll
+}
+
+// SetAutoSkip enables skipping missed data instead of returning
+// ErrStaleReader, see Reader.SetAutoSkip.
+func (r *SharedReader[T]) SetAutoSkip(enabled bool) {
+	r.autoSkip = enabled
+}
+
+// Read reads data from the shared ring into p. If no data is available, Read
+// returns io.EOF, or waits if the reader is blocking and the writer was not
+// closed. Read returns io.ErrClosedPipe once the reader is closed.
+func (r *SharedReader[T]) Read(p []T) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for {
+		n, err := r.read(p)
+		if n > 0 || err != nil {
+			return n, err
+		}
+		// the lock is not held while waiting, so Close can interrupt it
+		time.Sleep(r.poll)
+	}
+}
+
+// read implements Read, returning no data and no error if a blocking reader
+// must wait.
+func (r *SharedReader[T]) read(p []T) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.ring
+	if s == nil {
+		return 0, io.ErrClosedPipe
+	}
+	for {
+		// closed is loaded first, so data written before closing is seen
+		closed := s.hdr.closed.Load()
+		head := s.hdr.head.Load()
+		if r.pos >= head {
+			if !r.block || closed {
+				return 0, io.EOF
+			}
+			return 0, nil
+		}
+
+		if oldest := head - s.size; r.pos < oldest {
+			if !r.autoSkip {
+				return 0, ErrStaleReader
+			}
+			r.pos = oldest
+		}
+
+		n := min(int64(len(p)), head-r.pos)
+		off := r.pos % s.size
+		c := copy(p[:n], s.data[off:])
+		copy(p[c:n], s.data)
+
+		// make sure the writer didn't overwrite the data while we copied it
+		if r.pos < s.hdr.pending.Load()-s.size {
+			if !r.autoSkip {
+				return 0, ErrStaleReader
+			}
+			continue
+		}
+
+		r.pos += n
+		return int(n), nil
+	}
+}
+
+// Close unmaps the shared ring, interrupting a blocking Read. Closing more
+// than once returns io.ErrClosedPipe.
+func (r *SharedReader[T]) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ring == nil {
+		return io.ErrClosedPipe
+	}
+	err := r.ring.close()
+	r.ring = nil
+	return err
+}
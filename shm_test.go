@@ -0,0 +1,167 @@
+//go:build linux || darwin || freebsd
+
+package ringslice
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShared(t *testing.T) {
+	type sample struct {
+		ID    uint32
+		Value float64
+	}
+
+	path := filepath.Join(t.TempDir(), "shm")
+	w, err := CreateShared[sample](path, 4)
+	if err != nil {
+		t.Errorf("failed to create shared ring, got err=%v", err)
+		return
+	}
+	defer w.Close()
+
+	r, err := OpenShared[sample](path)
+	if err != nil {
+		t.Errorf("failed to open shared ring, got err=%v", err)
+		return
+	}
+	defer r.Close()
+
+	w.Append(sample{1, 1.5}, sample{2, 2.5})
+
+	rbuf := make([]sample, 8)
+	n, err := r.Read(rbuf)
+	if !slices.Equal(rbuf[:n], []sample{{1, 1.5}, {2, 2.5}}) || err != nil {
+		t.Errorf("failed shared read test, got %v err=%v", rbuf[:n], err)
+	}
+
+	_, err = r.Read(rbuf)
+	if err != io.EOF {
+		t.Errorf("failed shared EOF test, got err=%v", err)
+	}
+
+	w.Append(sample{3, 0}, sample{4, 0}, sample{5, 0}, sample{6, 0}, sample{7, 0})
+	_, err = r.Read(rbuf)
+	if err != ErrStaleReader {
+		t.Errorf("failed shared stale test, got err=%v", err)
+	}
+
+	if _, err := CreateShared[*int](path+"2", 4); err != ErrNotPlain {
+		t.Errorf("failed shared pointer type test, got err=%v", err)
+	}
+}
+
+func TestSharedClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shm")
+	w, err := CreateShared[uint64](path, 4)
+	if err != nil {
+		t.Errorf("failed to create shared ring, got err=%v", err)
+		return
+	}
+
+	r, err := OpenShared[uint64](path)
+	if err != nil {
+		t.Errorf("failed to open shared ring, got err=%v", err)
+		return
+	}
+	defer r.Close()
+	r.SetBlocking(true, 0)
+
+	w.Append(1)
+	w.Close()
+
+	buf := make([]uint64, 4)
+	if n, err := r.Read(buf); n != 1 || buf[0] != 1 || err != nil {
+		t.Errorf("failed shared close test, expected 1, got %v err=%v", buf[:n], err)
+	}
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Errorf("failed shared close test, expected io.EOF, got %v", err)
+	}
+}
+
+func TestSharedUseAfterClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shm")
+	w, err := CreateShared[uint64](path, 4)
+	if err != nil {
+		t.Errorf("failed to create shared ring, got err=%v", err)
+		return
+	}
+	r, err := OpenShared[uint64](path)
+	if err != nil {
+		t.Errorf("failed to open shared ring, got err=%v", err)
+		return
+	}
+	r.SetBlocking(true, 0)
+
+	// a blocking read is interrupted by Close
+	res := make(chan error, 1)
+	go func() {
+		_, err := r.Read(make([]uint64, 4))
+		res <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := r.Close(); err != nil {
+		t.Errorf("failed shared reader close test, expected nil, got %v", err)
+	}
+	select {
+	case err := <-res:
+		if err != io.ErrClosedPipe {
+			t.Errorf("failed shared reader close test, expected io.ErrClosedPipe, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed shared reader close test, read still blocked")
+	}
+	if err := r.Close(); err != io.ErrClosedPipe {
+		t.Errorf("failed shared reader close test, expected io.ErrClosedPipe, got %v", err)
+	}
+
+	w.Close()
+	if _, err := w.Append(1); err != io.ErrClosedPipe {
+		t.Errorf("failed shared writer close test, expected io.ErrClosedPipe, got %v", err)
+	}
+	if n := w.TotalWritten(); n != 0 {
+		t.Errorf("failed shared writer close test, expected 0, got %d", n)
+	}
+	if err := w.Close(); err != io.ErrClosedPipe {
+		t.Errorf("failed shared writer close test, expected io.ErrClosedPipe, got %v", err)
+	}
+}
+
+func TestOpenSharedMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shm")
+	w, err := CreateShared[uint64](path, 4)
+	if err != nil {
+		t.Errorf("failed to create shared ring, got err=%v", err)
+		return
+	}
+	w.Close()
+
+	// file shorter than the size in its header
+	if err := os.Truncate(path, shmHeaderSize+8); err != nil {
+		t.Errorf("failed to truncate shared ring, got err=%v", err)
+		return
+	}
+	if _, err := OpenShared[uint64](path); err != ErrInvalidState {
+		t.Errorf("failed short file test, expected ErrInvalidState, got %v", err)
+	}
+
+	// size overflowing the mapping length
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Errorf("failed to open shared ring, got err=%v", err)
+		return
+	}
+	var size [8]byte
+	binary.NativeEndian.PutUint64(size[:], 1<<62)
+	f.WriteAt(size[:], 16)
+	f.Close()
+	if _, err := OpenShared[uint64](path); err != ErrInvalidSize {
+		t.Errorf("failed overflow test, expected ErrInvalidSize, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package ringslice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var ErrInvalidState = errors.New("invalid ringslice state data")
+
+const (
+	// stateMagic identifies data written by SaveState
+	stateMagic = "RSS\x01"
+
+	// maxStateElement is the largest encoded element LoadState accepts
+	maxStateElement = 1 << 30
+
+	// maxStateSize is the largest buffer size restored states can have
+	maxStateSize = 1 << 30
+)
+
+// checkState validates the positions of a restored state holding count
+// elements, before the buffer is allocated.
+func checkState(size, head, count int64) error {
+	if size <= 0 || size > maxStateSize || head < 0 || count < 0 || count > size || count > head {
+		return ErrInvalidState
+	}
+	return nil
+}
+
+// SaveState writes the full state of the buffer (retained elements and
+// positions) to dst, using enc to encode each element, so it can later be
+// restored with LoadState. The buffer is locked for reading while the state
+// is written.
+func (w *Writer[T]) SaveState(dst io.Writer, enc func(T) ([]byte, error)) error {
+	out := bufio.NewWriter(dst)
+
+	w.mutex.RLock()
+	head := w.head.Load()
+	oldest := w.oldest(head)
+
+	var hdr []byte
+	hdr = append(hdr, stateMagic...)
+	hdr = binary.AppendUvarint(hdr, uint64(w.size))
+	hdr = binary.AppendUvarint(hdr, uint64(head))
+	hdr = binary.AppendUvarint(hdr, uint64(head-oldest))
+	_, err := out.Write(hdr)
+
+	if err == nil {
+		var lbuf [binary.MaxVarintLen64]byte
+		err = w.data.segments(oldest, head-oldest, func(seg []T) error {
+			for _, v := range seg {
+				buf, err := enc(v)
+				if err != nil {
+					return err
+				}
+				out.Write(lbuf[:binary.PutUvarint(lbuf[:], uint64(len(buf)))])
+				if _, err = out.Write(buf); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	w.mutex.RUnlock()
+
+	if err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// LoadState restores a buffer previously saved with SaveState, using dec to
+// decode each element. The returned writer has the same size, positions and
+// retained elements as the saved one.
+func LoadState[T any](src io.Reader, dec func([]byte) (T, error)) (*Writer[T], error) {
+	return loadState(bufio.NewReader(src), dec)
+}
+
+// stateReader is the input loadState needs
+type stateReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func loadState[T any](in stateReader, dec func([]byte) (T, error)) (*Writer[T], error) {
+	size, head, values, err := readState(in, dec)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := New[T](size)
+	if err != nil {
+		return nil, err
+	}
+	w.data.copyIn(head-int64(len(values)), values)
+	w.restore(head, head-int64(len(values)))
+	return w, nil
+}
+
+// readState reads and validates a state written by SaveState, returning the
+// buffer's size and head and the retained elements. Elements are read before
+// the caller allocates the buffer, so that a header claiming more elements
+// than present fails without allocating for them.
+func readState[T any](in stateReader, dec func([]byte) (T, error)) (int64, int64, []T, error) {
+	magic := make([]byte, len(stateMagic))
+	if _, err := io.ReadFull(in, magic); err != nil {
+		return 0, 0, nil, err
+	}
+	if string(magic) != stateMagic {
+		return 0, 0, nil, ErrInvalidState
+	}
+
+	var hdr [3]uint64
+	for i := range hdr {
+		v, err := binary.ReadUvarint(in)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		hdr[i] = v
+	}
+	size, head, count := int64(hdr[0]), int64(hdr[1]), int64(hdr[2])
+	if err := checkState(size, head, count); err != nil {
+		return 0, 0, nil, err
+	}
+
+	var values []T
+	var buf bytes.Buffer
+	for range count {
+		l, err := binary.ReadUvarint(in)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		if l > maxStateElement {
+			return 0, 0, nil, ErrInvalidState
+		}
+		buf.Reset()
+		if _, err = io.CopyN(&buf, in, int64(l)); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, 0, nil, err
+		}
+		v, err := dec(buf.Bytes())
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		values = append(values, v)
+	}
+	return size, head, values, nil
+}
+
+// restore sets the writer's positions, for a writer that has no reader yet.
+func (w *Writer[T]) restore(head, tail int64) {
+	w.head.Store(head)
+	w.pending.Store(head)
+	w.tail.Store(tail)
+}
@@ -0,0 +1,105 @@
+package ringslice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSaveState(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append(1, 2, 3, 4, 5, 6)
+
+	buf := &bytes.Buffer{}
+	enc := func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil }
+	if err := w.SaveState(buf, enc); err != nil {
+		t.Errorf("failed SaveState test, got err=%v", err)
+	}
+
+	w2, err := LoadState(buf, func(b []byte) (int, error) { return strconv.Atoi(string(b)) })
+	if err != nil {
+		t.Errorf("failed LoadState test, got err=%v", err)
+		return
+	}
+
+	if w2.Size() != 4 || w2.TotalWritten() != 6 {
+		t.Errorf("failed LoadState test, got size=%d written=%d", w2.Size(), w2.TotalWritten())
+	}
+	if s := w2.Snapshot(); !slices.Equal(s, []int{3, 4, 5, 6}) {
+		t.Errorf("failed LoadState test, expected [3 4 5 6], got %v", s)
+	}
+
+	w2.Append(7)
+	if s := w2.Snapshot(); !slices.Equal(s, []int{4, 5, 6, 7}) {
+		t.Errorf("failed LoadState append test, expected [4 5 6 7], got %v", s)
+	}
+
+	if _, err = LoadState(bytes.NewReader([]byte("garbage")), func(b []byte) (int, error) { return 0, nil }); err != ErrInvalidState {
+		t.Errorf("failed invalid LoadState test, got err=%v", err)
+	}
+}
+
+func TestLoadStateMalformed(t *testing.T) {
+	header := func(magic string, fields ...uint64) []byte {
+		b := []byte(magic)
+		for _, f := range fields {
+			b = binary.AppendUvarint(b, f)
+		}
+		return b
+	}
+	dec := func(b []byte) (int, error) { return 0, nil }
+
+	for _, data := range [][]byte{
+		header(stateMagic, 1<<62, 0, 0),
+		header(stateMagic, 4, 8, 5),
+		header(stateMagic, maxStateSize+1, 1, 1),
+	} {
+		if _, err := LoadState(bytes.NewReader(data), dec); err != ErrInvalidState {
+			t.Errorf("failed malformed LoadState test, expected ErrInvalidState, got %v", err)
+		}
+	}
+
+	// elements announced by the header are missing
+	data := header(stateMagic, 1<<20, 1<<20, 1<<20)
+	if _, err := LoadState(bytes.NewReader(data), dec); err != io.EOF {
+		t.Errorf("failed truncated LoadState test, expected io.EOF, got %v", err)
+	}
+	data = header(stateMagic, 4, 1, 1, maxStateElement)
+	if _, err := LoadState(bytes.NewReader(data), dec); err != io.ErrUnexpectedEOF {
+		t.Errorf("failed truncated LoadState test, expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	var w Writer[int]
+	var gobData bytes.Buffer
+	gob.NewEncoder(&gobData).Encode(gobState[int]{Size: 1 << 62})
+	if err := w.GobDecode(gobData.Bytes()); err != ErrInvalidState {
+		t.Errorf("failed malformed GobDecode test, expected ErrInvalidState, got %v", err)
+	}
+	if err := w.UnmarshalJSON([]byte(`{"size":4611686018427387904,"data":[]}`)); err != ErrInvalidState {
+		t.Errorf("failed malformed UnmarshalJSON test, expected ErrInvalidState, got %v", err)
+	}
+	order := byte(0)
+	if nativeBig {
+		order = 1
+	}
+	bin := append([]byte(binaryMagic), order)
+	bin = append(bin, header("", 8, 1<<62, 0, 0)...)
+	if err := w.UnmarshalBinary(bin); err != ErrInvalidState {
+		t.Errorf("failed malformed UnmarshalBinary test, expected ErrInvalidState, got %v", err)
+	}
+	imp := append([]byte(exportMagic), header("", 4)...)
+	imp = append(imp, "json"...)
+	imp = append(imp, header(stateMagic, 1<<62, 0, 0)...)
+	if err := w.ImportFrom(bytes.NewReader(imp), JSONCodec[int]{}); err != ErrInvalidState {
+		t.Errorf("failed malformed ImportFrom test, expected ErrInvalidState, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package ringslice
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// Stats holds counters describing the activity of a Writer.
+type Stats struct {
+	Writes      int64 // number of write calls
+	Elements    int64 // total number of elements written
+	Wraps       int64 // number of times writes wrapped around the buffer
+	Overwritten int64 // elements overwritten before the slowest reader read them
+	Readers     int   // number of open readers
+	Blocked     int   // number of readers waiting for data
+
+	WriteSizes SizeHistogram // number of elements per write call
+	ReadSizes  SizeHistogram // number of elements per read call returning data
+}
+
+// SizeHistogram counts calls by number of elements, using power of two
+// buckets: bucket 0 counts empty calls, and bucket i counts calls with a size
+// in [2^(i-1), 2^i).
+type SizeHistogram struct {
+	Buckets [65]int64
+	Sum     int64 // total number of elements of all calls
+}
+
+// Count returns the total number of calls in the histogram.
+func (h *SizeHistogram) Count() int64 {
+	var res int64
+	for _, v := range h.Buckets {
+		res += v
+	}
+	return res
+}
+
+// UpperBound returns the largest size counted by bucket i.
+func (h *SizeHistogram) UpperBound(i int) uint64 {
+	if i == 0 {
+		return 0
+	}
+	return 1<<i - 1
+}
+
+// sizeCounter is a concurrently updated SizeHistogram
+type sizeCounter struct {
+	buckets [65]atomic.Int64
+	sum     atomic.Int64
+}
+
+func (c *sizeCounter) add(n int) {
+	c.buckets[bits.Len64(uint64(n))].Add(1)
+	c.sum.Add(int64(n))
+}
+
+func (c *sizeCounter) load() (res SizeHistogram) {
+	for i := range c.buckets {
+		res.Buckets[i] = c.buckets[i].Load()
+	}
+	res.Sum = c.sum.Load()
+	return
+}
+
+// Stats returns the current counters of the buffer. Overwritten counts
+// elements that were dropped while at least one open reader had not read
+// them yet, which means some consumer missed data.
+func (w *Writer[T]) Stats() Stats {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head := w.head.Load()
+	return Stats{
+		Writes:      w.writes,
+		Elements:    head,
+		Wraps:       head / w.size,
+		Overwritten: w.overwritten,
+		Readers:     int(w.readers.Load()),
+		Blocked:     int(w.blocked.Load()),
+		WriteSizes:  w.wsizes.load(),
+		ReadSizes:   w.rsizes.load(),
+	}
+}
+
+// account records statistics for a write moving the head from head to end,
+// before the data is overwritten. The caller must hold the lock.
+func (w *Writer[T]) account(head, end int64) {
+	lost := end - w.size
+	if len(w.active) == 0 || lost <= w.oldest(head) {
+		return
+	}
+
+	slowest := lost
+	for r := range w.active {
+		slowest = min(slowest, r.pos.Load())
+	}
+	if n := lost - max(slowest, w.oldest(head)); n > 0 {
+		w.overwritten += n
+		if h := w.instrument(); h != nil {
+			h.OnDrop(n)
+		}
+	}
+}
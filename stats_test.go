@@ -0,0 +1,78 @@
+package ringslice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	fast := w.Reader()
+	slow := w.Reader()
+	defer fast.Close()
+
+	w.Append(1, 2, 3)
+	fast.Read(make([]int, 4))
+	w.Append(4, 5, 6)
+	w.Append(7, 8, 9)
+
+	st := w.Stats()
+	if st.Writes != 3 || st.Elements != 9 || st.Wraps != 2 {
+		t.Errorf("failed stats test, expected 3 writes, 9 elements and 2 wraps, got %+v", st)
+	}
+	// the slow reader missed 1..5
+	if st.Overwritten != 5 || st.Readers != 2 {
+		t.Errorf("failed stats overwritten test, expected 5 overwritten by 2 readers, got %+v", st)
+	}
+
+	slow.Close()
+	fast.Reset()
+	w.Append(10, 11)
+	if st := w.Stats(); st.Overwritten != 5 || st.Readers != 1 {
+		t.Errorf("failed stats closed reader test, expected 5 overwritten by 1 reader, got %+v", st)
+	}
+
+	blocking := w.BlockingCurrentReader()
+	defer blocking.Close()
+	go blocking.Read(make([]int, 1))
+	for i := 0; i < 100 && w.Stats().Blocked != 1; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if st := w.Stats(); st.Blocked != 1 {
+		t.Errorf("failed stats blocked test, expected 1 blocked reader, got %+v", st)
+	}
+	w.Append(12)
+}
+
+func TestSizeHistograms(t *testing.T) {
+	w, err := New[byte](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	r := w.Reader()
+	defer r.Close()
+
+	w.Write([]byte("a"))
+	w.Write([]byte("abcde"))
+	w.Write([]byte("abcdef"))
+	for i := 0; i < 3; i++ {
+		r.Read(make([]byte, 1))
+	}
+
+	st := w.Stats()
+	if st.WriteSizes.Buckets[1] != 1 || st.WriteSizes.Buckets[3] != 2 || st.WriteSizes.Count() != 3 || st.WriteSizes.Sum != 12 {
+		t.Errorf("failed write size histogram test, got %v sum=%d", st.WriteSizes.Buckets[:4], st.WriteSizes.Sum)
+	}
+	if st.ReadSizes.Buckets[1] != 3 || st.ReadSizes.Count() != 3 || st.ReadSizes.Sum != 3 {
+		t.Errorf("failed read size histogram test, got %v sum=%d", st.ReadSizes.Buckets[:4], st.ReadSizes.Sum)
+	}
+	if st.WriteSizes.UpperBound(3) != 7 {
+		t.Errorf("failed histogram bound test, expected 7, got %d", st.WriteSizes.UpperBound(3))
+	}
+}
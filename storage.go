@@ -0,0 +1,123 @@
+package ringslice
+
+// storage holds the elements of a ring, either as a single contiguous slice,
+// or as a list of fixed-size pages that are only allocated once written to
+// and can be released when their content is discarded.
+type storage[T any] struct {
+	pages    [][]T
+	pageSize int64
+	size     int64
+	lazy     bool // pages are allocated on demand
+}
+
+func newStorage[T any](size, pageSize int64) storage[T] {
+	if pageSize <= 0 || pageSize >= size {
+		return storage[T]{
+			pages:    [][]T{make([]T, size)},
+			pageSize: size,
+			size:     size,
+		}
+	}
+
+	return storage[T]{
+		pages:    make([][]T, (size+pageSize-1)/pageSize),
+		pageSize: pageSize,
+		size:     size,
+		lazy:     true,
+	}
+}
+
+// page returns the page containing offset off and the offset in that page,
+// allocating the page if needed.
+func (s *storage[T]) page(off int64) ([]T, int64) {
+	pg := off / s.pageSize
+	if s.pages[pg] == nil {
+		s.pages[pg] = make([]T, min(s.pageSize, s.size-pg*s.pageSize))
+	}
+	return s.pages[pg], off % s.pageSize
+}
+
+// copyIn stores src at absolute position pos, wrapping around the end of the
+// ring as needed. len(src) must not exceed the ring's size.
+func (s *storage[T]) copyIn(pos int64, src []T) {
+	off := pos % s.size
+	for len(src) > 0 {
+		pg, po := s.page(off)
+		c := copy(pg[po:], src)
+		src = src[c:]
+		off += int64(c)
+		if off >= s.size {
+			off = 0
+		}
+	}
+}
+
+// copyOut copies data starting at absolute position pos into dst, wrapping
+// around the end of the ring as needed.
+func (s *storage[T]) copyOut(dst []T, pos int64) {
+	off := pos % s.size
+	for len(dst) > 0 {
+		c := copy(dst, s.pages[off/s.pageSize][off%s.pageSize:])
+		dst = dst[c:]
+		off += int64(c)
+		if off >= s.size {
+			off = 0
+		}
+	}
+}
+
+// segments calls fn for each contiguous part of the storage holding the n
+// elements starting at absolute position pos, stopping at the first error.
+func (s *storage[T]) segments(pos, n int64, fn func([]T) error) error {
+	off := pos % s.size
+	for n > 0 {
+		pg := s.pages[off/s.pageSize][off%s.pageSize:]
+		c := min(n, int64(len(pg)))
+		if err := fn(pg[:c]); err != nil {
+			return err
+		}
+		n -= c
+		off += c
+		if off >= s.size {
+			off = 0
+		}
+	}
+	return nil
+}
+
+// at returns the element at absolute position pos.
+func (s *storage[T]) at(pos int64) T {
+	off := pos % s.size
+	return s.pages[off/s.pageSize][off%s.pageSize]
+}
+
+// set stores v at absolute position pos.
+func (s *storage[T]) set(pos int64, v T) {
+	pg, po := s.page(pos % s.size)
+	pg[po] = v
+}
+
+// release discards everything but the elements in the absolute range
+// [from, to), clearing discarded values so they can be garbage collected and
+// freeing whole pages of lazily allocated storage.
+func (s *storage[T]) release(from, to int64) {
+	off := to % s.size
+	n := s.size - (to - from)
+	for n > 0 {
+		pgIdx := off / s.pageSize
+		po := off % s.pageSize
+		c := min(n, min(s.pageSize, s.size-pgIdx*s.pageSize)-po)
+		if pg := s.pages[pgIdx]; pg != nil {
+			if s.lazy && po == 0 && c == int64(len(pg)) {
+				s.pages[pgIdx] = nil
+			} else {
+				clear(pg[po : po+c])
+			}
+		}
+		n -= c
+		off += c
+		if off >= s.size {
+			off = 0
+		}
+	}
+}
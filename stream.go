@@ -0,0 +1,99 @@
+package ringslice
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+var ErrCodecMismatch = errors.New("ringslice stream was exported with a different codec")
+
+// exportMagic identifies data written by ExportTo
+const exportMagic = "RSX\x02"
+
+// Codec encodes and decodes elements of type T. Name identifies the encoding
+// and is checked on import.
+type Codec[T any] interface {
+	Name() string
+	Encode(v T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec is a Codec encoding elements as JSON.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Name() string { return "json" }
+
+func (JSONCodec[T]) Encode(v T) ([]byte, error) { return json.Marshal(v) }
+
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// ExportTo streams the retained window of the buffer to dst, so it can be
+// reconstructed with ImportFrom. The stream starts with the codec's name,
+// followed by the buffer's state as written by SaveState. The buffer is
+// locked for reading during the export.
+func (w *Writer[T]) ExportTo(dst io.Writer, c Codec[T]) error {
+	out := bufio.NewWriter(dst)
+
+	var hdr []byte
+	hdr = append(hdr, exportMagic...)
+	hdr = binary.AppendUvarint(hdr, uint64(len(c.Name())))
+	hdr = append(hdr, c.Name()...)
+	out.Write(hdr)
+
+	if err := w.SaveState(out, c.Encode); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// ImportFrom reconstructs a buffer from a stream written by ExportTo with a
+// codec of the same name. It is meant to be called on a zero Writer and must
+// not be called on a Writer in use. The stream is fully read and validated
+// before the buffer is allocated, and ImportFrom does not read past its end
+// if src implements io.ByteReader.
+func (w *Writer[T]) ImportFrom(src io.Reader, c Codec[T]) error {
+	in, ok := src.(stateReader)
+	if !ok {
+		in = bufio.NewReader(src)
+	}
+
+	magic := make([]byte, len(exportMagic))
+	if _, err := io.ReadFull(in, magic); err != nil {
+		return err
+	}
+	if string(magic) != exportMagic {
+		return ErrInvalidState
+	}
+
+	l, err := binary.ReadUvarint(in)
+	if err != nil {
+		return err
+	}
+	if l > 1024 {
+		return ErrInvalidState
+	}
+	name := make([]byte, l)
+	if _, err := io.ReadFull(in, name); err != nil {
+		return err
+	}
+	if string(name) != c.Name() {
+		return ErrCodecMismatch
+	}
+
+	size, head, values, err := readState(in, c.Decode)
+	if err != nil {
+		return err
+	}
+
+	w.init(size, 0)
+	w.data.copyIn(head-int64(len(values)), values)
+	w.restore(head, head-int64(len(values)))
+	return nil
+}
@@ -0,0 +1,71 @@
+package ringslice
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestExportImport(t *testing.T) {
+	w, err := New[int](300)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	for i := 0; i < 500; i++ {
+		w.Append(i)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := w.ExportTo(buf, JSONCodec[int]{}); err != nil {
+		t.Errorf("failed export test, got err=%v", err)
+		return
+	}
+	buf.WriteString("trailing")
+
+	var res Writer[int]
+	if err := res.ImportFrom(buf, JSONCodec[int]{}); err != nil {
+		t.Errorf("failed import test, got err=%v", err)
+		return
+	}
+	if res.TotalWritten() != 500 || res.Size() != 300 || !slices.Equal(res.Snapshot(), w.Snapshot()) {
+		t.Errorf("failed import test, expected %d elements, got %d", w.Len(), res.Len())
+	}
+	if buf.String() != "trailing" {
+		t.Errorf("failed import test, expected trailing data to be left, got %q", buf.String())
+	}
+}
+
+type hexCodec struct{ JSONCodec[int] }
+
+func (hexCodec) Name() string { return "hex" }
+
+func TestImportCodecMismatch(t *testing.T) {
+	w, _ := New[int](3)
+	w.Append(1, 2)
+
+	buf := &bytes.Buffer{}
+	w.ExportTo(buf, JSONCodec[int]{})
+
+	var res Writer[int]
+	if err := res.ImportFrom(buf, hexCodec{}); err != ErrCodecMismatch {
+		t.Errorf("failed import codec test, expected ErrCodecMismatch, got %v", err)
+	}
+}
+
+func TestImportTruncated(t *testing.T) {
+	w, _ := New[int](300)
+	for i := 0; i < 500; i++ {
+		w.Append(i)
+	}
+
+	buf := &bytes.Buffer{}
+	w.ExportTo(buf, JSONCodec[int]{})
+
+	// the buffer is only allocated once the whole stream was read
+	var res Writer[int]
+	data := buf.Bytes()
+	if err := res.ImportFrom(bytes.NewReader(data[:len(data)/2]), JSONCodec[int]{}); err == nil || res.Size() != 0 {
+		t.Errorf("failed truncated import test, expected error and no buffer, got size=%d err=%v", res.Size(), err)
+	}
+}
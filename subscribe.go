@@ -0,0 +1,52 @@
+package ringslice
+
+import "errors"
+
+// errStop stops an iteration over segments
+var errStop = errors.New("stop")
+
+// Subscribe returns a new blocking reader positioned at the buffer's edge
+// which only returns elements for which filter returns true. The filter is
+// evaluated in place before copying, so elements a subscriber is not
+// interested in are never copied. It returns nil if the writer is closed,
+// see NewSubscription.
+//
+// The filter is called while the buffer is locked for reading, and must not
+// call methods of the buffer or its readers.
+func (w *Writer[T]) Subscribe(filter func(T) bool) *Reader[T] {
+	r, _ := w.NewSubscription(filter)
+	return r
+}
+
+// NewSubscription is like Subscribe, but returns ErrWriterClosed instead of
+// a nil reader if the writer is closed.
+func (w *Writer[T]) NewSubscription(filter func(T) bool) (*Reader[T], error) {
+	r, err := w.newReaderErr(true, true)
+	if err != nil {
+		return nil, err
+	}
+	r.filter = filter
+	return r, nil
+}
+
+// copyFiltered copies elements in [pos, head) matching filter and not
+// expired to p, and returns how many were copied and the position following
+// the last element examined. The caller must hold the lock.
+func (w *Writer[T]) copyFiltered(p []T, pos, head int64, filter func(T) bool) (int64, int64) {
+	var n int64
+	now := w.clk().Now().UnixNano()
+	w.data.segments(pos, head-pos, func(seg []T) error {
+		for _, v := range seg {
+			if n == int64(len(p)) {
+				return errStop
+			}
+			pos++
+			if !w.expired(pos-1, now) && filter(v) {
+				p[n] = v
+				n++
+			}
+		}
+		return nil
+	})
+	return n, pos
+}
@@ -0,0 +1,62 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.Append(100, 102) // before subscribing
+
+	even := w.Subscribe(func(v int) bool { return v%2 == 0 })
+	defer even.Close()
+
+	w.Append(1, 2, 3, 4, 5, 6, 7, 8)
+
+	buf := make([]int, 3)
+	n, err := even.Read(buf)
+	if err != nil || !slices.Equal(buf[:n], []int{2, 4, 6}) {
+		t.Errorf("failed subscribe test, expected [2 4 6], got %v err=%v", buf[:n], err)
+	}
+	n, _ = even.Read(buf)
+	if !slices.Equal(buf[:n], []int{8}) {
+		t.Errorf("failed subscribe test, expected [8], got %v", buf[:n])
+	}
+
+	// odd elements only, blocks until a matching element
+	go w.Append(9, 11, 12)
+	n, _ = even.Read(buf)
+	if !slices.Equal(buf[:n], []int{12}) {
+		t.Errorf("failed subscribe blocking test, expected [12], got %v", buf[:n])
+	}
+}
+
+func TestSubscribeTTL(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	clock := NewManualClock(time.Unix(0, 0))
+	w.SetClock(clock)
+
+	r := w.Subscribe(func(v int) bool { return v%2 == 0 })
+	defer r.Close()
+	r.setBlocking(false)
+
+	w.WriteTTL([]int{1}, time.Second)
+	w.Write([]int{2})
+	w.WriteTTL([]int{4}, time.Second)
+	clock.Advance(2 * time.Second)
+
+	buf := make([]int, 4)
+	if n, err := r.Read(buf); n != 1 || err != nil || buf[0] != 2 {
+		t.Errorf("failed filtered TTL test, expected [2], got %v err=%v", buf[:n], err)
+	}
+}
@@ -0,0 +1,51 @@
+package ringslice
+
+import (
+	"io"
+	"sync"
+)
+
+// TeeWriter appends to a Writer and duplicates every write to a sink, for
+// example to archive everything to disk while keeping the in-memory buffer
+// for live readers.
+type TeeWriter[T any] struct {
+	w    *Writer[T]
+	sink func([]T) error
+	mu   sync.Mutex // keeps the sink in the same order as the buffer
+}
+
+// NewTeeWriter returns a TeeWriter writing to w and sink.
+func NewTeeWriter[T any](w *Writer[T], sink func([]T) error) *TeeWriter[T] {
+	return &TeeWriter[T]{w: w, sink: sink}
+}
+
+// IOSink returns a sink writing to dst, for use with NewTeeWriter on byte
+// buffers.
+func IOSink(dst io.Writer) func([]byte) error {
+	return func(p []byte) error {
+		_, err := dst.Write(p)
+		return err
+	}
+}
+
+// Append values to the buffer and the sink.
+func (t *TeeWriter[T]) Append(values ...T) (int, error) {
+	return t.Write(values)
+}
+
+// Write writes values to the buffer, then passes them to the sink. If the
+// sink fails, its error is returned even though the values were written to
+// the buffer.
+func (t *TeeWriter[T]) Write(values []T) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, err := t.w.Write(values)
+	if err != nil {
+		return n, err
+	}
+	if err = t.sink(values); err != nil {
+		return n, err
+	}
+	return n, nil
+}
@@ -0,0 +1,32 @@
+package ringslice
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	rbuf := make([]byte, 32)
+
+	w, err := New[byte](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	archive := &bytes.Buffer{}
+	tee := NewTeeWriter(w, IOSink(archive))
+	r := w.Reader()
+
+	tee.Write([]byte("hello"))
+	tee.Write([]byte("world"))
+
+	n, err := r.Read(rbuf)
+	if err != ErrStaleReader {
+		t.Errorf("failed tee test, expected stale reader, got n=%d err=%v", n, err)
+	}
+
+	if archive.String() != "helloworld" {
+		t.Errorf("failed tee test, expected helloworld in sink, got %q", archive.String())
+	}
+}
@@ -0,0 +1,153 @@
+package ringslice
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+var ErrNoTimestamps = errors.New("timestamps are not enabled on this buffer")
+
+// SetTimestamps enables or disables recording the time at which each element
+// is written. Elements written while timestamps were disabled are considered
+// to have been written at the Unix epoch. Timestamps never go backward: if the clock does, new
+// elements get the same timestamp as the previous ones.
+func (w *Writer[T]) SetTimestamps(enabled bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !enabled {
+		w.times = nil
+		return
+	}
+	w.enableTimestamps()
+}
+
+// enableTimestamps allocates timestamps storage if needed. The caller must
+// hold the lock.
+func (w *Writer[T]) enableTimestamps() {
+	if w.times != nil {
+		return
+	}
+	pageSize := int64(0)
+	if w.data.lazy {
+		pageSize = w.data.pageSize
+	}
+	times := newStorage[int64](w.size, pageSize)
+	w.times = &times
+}
+
+// SetMaxAge sets the maximum age of elements: elements written more than d
+// ago are considered expired and are skipped by readers and snapshots even if
+// the buffer is not full. This enables timestamps (see SetTimestamps). A zero
+// duration disables age based retention. Expiration does not apply to
+// ReadMsg and ReadUnit, which need to resume on a message boundary.
+func (w *Writer[T]) SetMaxAge(d time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.maxAge.Store(int64(max(d, 0)))
+	if d > 0 {
+		w.enableTimestamps()
+	}
+}
+
+// live returns the position of the oldest retained element that has not
+// expired. The caller must hold the lock.
+func (w *Writer[T]) live(head int64) int64 {
+	maxAge := time.Duration(w.maxAge.Load())
+	if maxAge == 0 || w.times == nil {
+		return w.oldest(head)
+	}
+	pos, _ := w.searchTime(w.clk().Now().Add(-maxAge))
+	return w.ceil(pos)
+}
+
+// stamp records the current time for elements in [start, end). The caller
+// must hold the lock.
+func (w *Writer[T]) stamp(start, end int64) {
+	now := max(w.clk().Now().UnixNano(), w.lastTime)
+	w.lastTime = now
+	for pos := max(start, end-w.size); pos < end; pos++ {
+		w.times.set(pos, now)
+	}
+}
+
+// searchTime returns the position of the first retained element written at
+// or after t, or head if there is none. Since timestamps never go backward,
+// this is a binary search over the retained window, which takes O(log n)
+// lookups without any additional index. The caller must hold the lock.
+func (w *Writer[T]) searchTime(t time.Time) (int64, error) {
+	if w.times == nil {
+		return 0, ErrNoTimestamps
+	}
+
+	head := w.head.Load()
+	oldest := w.oldest(head)
+	ts := unixNano(t)
+	i := sort.Search(int(head-oldest), func(i int) bool {
+		return w.times.at(oldest+int64(i)) >= ts
+	})
+	return oldest + int64(i), nil
+}
+
+// unixNano returns t as nanoseconds since the Unix epoch, clamping times which
+// cannot be represented.
+func unixNano(t time.Time) int64 {
+	switch {
+	case t.Before(minTime):
+		return math.MinInt64
+	case t.After(maxTime):
+		return math.MaxInt64
+	}
+	return t.UnixNano()
+}
+
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// ReaderSince returns a new reader positioned at the first retained element
+// written at or after t, which requires timestamps to be enabled (see
+// SetTimestamps).
+func (w *Writer[T]) ReaderSince(t time.Time) (*Reader[T], error) {
+	return w.readerSince(t, false)
+}
+
+// BlockingReaderSince is like ReaderSince, but returns a blocking reader.
+func (w *Writer[T]) BlockingReaderSince(t time.Time) (*Reader[T], error) {
+	return w.readerSince(t, true)
+}
+
+func (w *Writer[T]) readerSince(t time.Time, block bool) (*Reader[T], error) {
+	r, err := w.newReaderErr(block, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.SeekToTime(t); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// SeekToTime positions the reader at the first retained element written at
+// or after t, found by binary search over the buffer's timestamps, so the
+// following reads return elements written since t. If all elements are older
+// than t, the reader is positioned after the latest element. It returns
+// ErrNoTimestamps if timestamps are not enabled (see SetTimestamps).
+func (r *Reader[T]) SeekToTime(t time.Time) error {
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	pos, err := r.w.searchTime(t)
+	if err != nil {
+		return err
+	}
+	r.pos.Store(pos)
+	r.ahead = nil
+	r.release()
+	return nil
+}
@@ -0,0 +1,120 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReaderSince(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if _, err := w.ReaderSince(time.Now()); err != ErrNoTimestamps {
+		t.Errorf("failed reader since test, expected ErrNoTimestamps, got %v", err)
+	}
+
+	w.Append(1) // no timestamp
+	w.SetTimestamps(true)
+	w.Append(2, 3)
+	time.Sleep(2 * time.Millisecond)
+	mark := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	w.Append(4, 5)
+
+	r, err := w.ReaderSince(mark)
+	if err != nil {
+		t.Errorf("failed reader since test, got err=%v", err)
+		return
+	}
+	defer r.Close()
+
+	buf := make([]int, 8)
+	n, _ := r.Read(buf)
+	if !slices.Equal(buf[:n], []int{4, 5}) {
+		t.Errorf("failed reader since test, expected [4 5], got %v", buf[:n])
+	}
+
+	r2, _ := w.ReaderSince(time.Time{})
+	defer r2.Close()
+	n, _ = r2.Read(buf)
+	if !slices.Equal(buf[:n], []int{1, 2, 3, 4, 5}) {
+		t.Errorf("failed reader since zero test, expected [1 2 3 4 5], got %v", buf[:n])
+	}
+
+	r3, _ := w.ReaderSince(time.Now().Add(time.Hour))
+	defer r3.Close()
+	if _, err := r3.Read(buf); err == nil {
+		t.Errorf("failed reader since future test, expected EOF")
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.SetMaxAge(20 * time.Millisecond)
+
+	r := w.Reader()
+	defer r.Close()
+
+	w.Append(1, 2)
+	time.Sleep(30 * time.Millisecond)
+	w.Append(3)
+
+	if s := w.Snapshot(); !slices.Equal(s, []int{3}) {
+		t.Errorf("failed max age snapshot test, expected [3], got %v", s)
+	}
+
+	buf := make([]int, 8)
+	n, err := r.Read(buf)
+	if err != nil || !slices.Equal(buf[:n], []int{3}) {
+		t.Errorf("failed max age read test, expected [3], got %v err=%v", buf[:n], err)
+	}
+
+	w.SetMaxAge(0)
+	if s := w.Snapshot(); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("failed max age disable test, expected [1 2 3], got %v", s)
+	}
+}
+
+func TestSeekToTime(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	r := w.Reader()
+	defer r.Close()
+
+	if err := r.SeekToTime(time.Now()); err != ErrNoTimestamps {
+		t.Errorf("failed seek to time test, expected ErrNoTimestamps, got %v", err)
+	}
+
+	w.SetTimestamps(true)
+	var marks []time.Time
+	for i := 0; i < 6; i++ {
+		time.Sleep(time.Millisecond)
+		marks = append(marks, time.Now())
+		w.Append(i)
+	}
+
+	buf := make([]int, 4)
+	for _, c := range []struct {
+		mark int
+		exp  []int
+	}{{4, []int{4, 5}}, {0, []int{2, 3, 4, 5}}, {3, []int{3, 4, 5}}} {
+		if err := r.SeekToTime(marks[c.mark]); err != nil {
+			t.Errorf("failed seek to time test, got err=%v", err)
+		}
+		n, _ := r.Read(buf)
+		if !slices.Equal(buf[:n], c.exp) {
+			t.Errorf("failed seek to time test, expected %v, got %v", c.exp, buf[:n])
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package ringslice
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// SetTracing enables or disables runtime/trace annotations: when enabled and
+// an execution trace is being recorded, the time readers block waiting for
+// data and writers block waiting for pinned readers is recorded as regions
+// of type "ringslice.ReadWait" and "ringslice.WriteWait".
+func (w *Writer[T]) SetTracing(enabled bool) {
+	w.tracing.Store(enabled)
+}
+
+// traceRegion starts a trace region of the given type if tracing is enabled,
+// or returns nil.
+func (w *Writer[T]) traceRegion(typ string) *trace.Region {
+	if !w.tracing.Load() || !trace.IsEnabled() {
+		return nil
+	}
+	return trace.StartRegion(context.Background(), typ)
+}
+
+// endRegion ends a region returned by traceRegion.
+func endRegion(r *trace.Region) {
+	if r != nil {
+		r.End()
+	}
+}
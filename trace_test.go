@@ -0,0 +1,38 @@
+package ringslice
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+	"time"
+)
+
+func TestTracing(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	w.SetTracing(true)
+
+	buf := &bytes.Buffer{}
+	if err := trace.Start(buf); err != nil {
+		t.Skipf("cannot start trace: %v", err)
+	}
+
+	r := w.BlockingReader()
+	defer r.Close()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Append(1)
+	}()
+	v, err := r.ReadOne()
+	trace.Stop()
+
+	if v != 1 || err != nil {
+		t.Errorf("failed traced read test, expected 1, got %d err=%v", v, err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("ringslice.ReadWait")) {
+		t.Errorf("failed tracing test, region not found in trace")
+	}
+}
@@ -0,0 +1,92 @@
+package ringslice
+
+import "time"
+
+// WriteTTL writes values to the buffer like Write, with a time to live: once
+// ttl has elapsed, the values are considered expired and are filtered out of
+// reads and snapshots. A zero ttl means the values do not expire, unless a
+// TTL function was set with SetTTLFunc.
+//
+// Expiration does not apply to ReadMsg and ReadUnit, nor to the lock-free
+// accessors such as Len.
+func (w *Writer[T]) WriteTTL(values []T, ttl time.Duration) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.enableTTL()
+	w.ttl = ttl
+	defer func() { w.ttl = 0 }()
+
+	return w.writeAll(values)
+}
+
+// SetTTLFunc sets a function returning the time to live of each element
+// written without an explicit TTL (see WriteTTL). A TTL of zero or less means
+// the element does not expire. Passing nil removes the function.
+func (w *Writer[T]) SetTTLFunc(fn func(T) time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.ttlFunc = fn
+	if fn != nil {
+		w.enableTTL()
+	}
+}
+
+// enableTTL allocates the expiration storage if needed. The caller must hold
+// the lock.
+func (w *Writer[T]) enableTTL() {
+	if w.expires != nil {
+		return
+	}
+	pageSize := int64(0)
+	if w.data.lazy {
+		pageSize = w.data.pageSize
+	}
+	expires := newStorage[int64](w.size, pageSize)
+	w.expires = &expires
+	w.hasTTL.Store(true)
+}
+
+// setExpiry records the expiration time of values written at pos. The
+// caller must hold the lock.
+func (w *Writer[T]) setExpiry(pos int64, values []T) {
+	now := w.clk().Now()
+	for i, v := range values {
+		ttl := w.ttl
+		if ttl == 0 && w.ttlFunc != nil {
+			ttl = w.ttlFunc(v)
+		}
+		var exp int64
+		if ttl > 0 {
+			exp = now.Add(ttl).UnixNano()
+		}
+		w.expires.set(pos+int64(i), exp)
+	}
+}
+
+// expired returns true if the element at pos expired at time now, in
+// nanoseconds. The caller must hold the lock.
+func (w *Writer[T]) expired(pos, now int64) bool {
+	if w.expires == nil {
+		return false
+	}
+	exp := w.expires.at(pos)
+	return exp != 0 && exp <= now
+}
+
+// filterExpired removes expired elements from p, which holds the elements
+// starting at pos, and returns the number of elements left. The caller must
+// hold the lock.
+func (w *Writer[T]) filterExpired(p []T, pos int64) int64 {
+	now := w.clk().Now().UnixNano()
+	n := 0
+	for i := range p {
+		if exp := w.expires.at(pos + int64(i)); exp == 0 || exp > now {
+			p[n] = p[i]
+			n++
+		}
+	}
+	clear(p[n:])
+	return int64(n)
+}
@@ -0,0 +1,51 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTTL(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+
+	w.Append(1)
+	w.WriteTTL([]int{2, 3}, 10*time.Millisecond)
+	w.SetTTLFunc(func(v int) time.Duration {
+		if v%2 == 0 {
+			return 10 * time.Millisecond
+		}
+		return 0
+	})
+	w.Append(4, 5)
+	w.WriteTTL([]int{6}, time.Hour)
+
+	if s := w.Snapshot(); !slices.Equal(s, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("failed ttl snapshot test, expected [1 2 3 4 5 6], got %v", s)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if s := w.Snapshot(); !slices.Equal(s, []int{1, 5, 6}) {
+		t.Errorf("failed ttl expired snapshot test, expected [1 5 6], got %v", s)
+	}
+
+	buf := make([]int, 2)
+	var res []int
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			break
+		}
+		res = append(res, buf[:n]...)
+	}
+	if !slices.Equal(res, []int{1, 5, 6}) {
+		t.Errorf("failed ttl read test, expected [1 5 6], got %v", res)
+	}
+}
@@ -0,0 +1,76 @@
+package ringslice
+
+// Tx gives access to a Writer within Do, while the buffer is locked.
+type Tx[T any] struct {
+	w *Writer[T]
+}
+
+// Do calls fn with a Tx for the buffer, which is locked for the duration of
+// the call, so that sequences of operations performed with tx are atomic.
+// The Tx must not be used once fn returns, and fn must not call methods of
+// the Writer or its readers.
+func (w *Writer[T]) Do(fn func(tx *Tx[T])) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	tx := &Tx[T]{w: w}
+	defer func() { tx.w = nil }()
+	fn(tx)
+}
+
+// Len returns the number of elements retained in the buffer.
+func (tx *Tx[T]) Len() int64 {
+	return tx.w.Len()
+}
+
+// Last returns the last element retained in the buffer, or false if there is
+// none.
+func (tx *Tx[T]) Last() (T, bool) {
+	return tx.w.last()
+}
+
+// Append writes values to the buffer. Since waiting would release the lock,
+// it returns ErrBufferFull instead of waiting for pinned readers to make room,
+// and ErrPaused if the writer is paused.
+func (tx *Tx[T]) Append(values ...T) (int, error) {
+	w := tx.w
+	if w.paused {
+		return 0, ErrPaused
+	}
+	if w.free(len(values)) < len(values) {
+		return 0, ErrBufferFull
+	}
+	return w.writeAll(values)
+}
+
+// Discard discards the n oldest elements retained in the buffer, see
+// Writer.Truncate.
+func (tx *Tx[T]) Discard(n int64) {
+	tx.w.truncate(tx.Len() - max(n, 0))
+}
+
+// AppendIf appends v only if fn, called with the last element of the buffer
+// while the buffer is locked, returns true. ok is false if the buffer holds
+// no element. It returns whether v was appended. Like Tx.Append, it returns
+// ErrBufferFull instead of waiting for pinned readers.
+func (w *Writer[T]) AppendIf(fn func(last T, ok bool) bool, v T) (res bool, err error) {
+	w.Do(func(tx *Tx[T]) {
+		if !fn(tx.Last()) {
+			return
+		}
+		if _, err = tx.Append(v); err == nil {
+			res = true
+		}
+	})
+	return
+}
+
+// last returns the last element retained in the buffer, or false if there is
+// none. The caller must hold the lock.
+func (w *Writer[T]) last() (T, bool) {
+	head := w.head.Load()
+	if w.oldest(head) >= head {
+		return empty[T](), false
+	}
+	return w.data.at(head - 1), true
+}
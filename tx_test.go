@@ -0,0 +1,105 @@
+package ringslice
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestAppendIf(t *testing.T) {
+	w, err := New[string](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	changed := func(state string) func(string, bool) bool {
+		return func(last string, ok bool) bool { return !ok || last != state }
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.AppendIf(changed("up"), "up")
+		}()
+	}
+	wg.Wait()
+
+	if ok, err := w.AppendIf(changed("up"), "up"); ok || err != nil {
+		t.Errorf("failed AppendIf test, expected no append, got %v err=%v", ok, err)
+	}
+	if ok, err := w.AppendIf(changed("down"), "down"); !ok || err != nil {
+		t.Errorf("failed AppendIf test, expected append, got %v err=%v", ok, err)
+	}
+	if s := w.Snapshot(); !slices.Equal(s, []string{"up", "down"}) {
+		t.Errorf("failed AppendIf test, expected [up down], got %v", s)
+	}
+
+	w.Close()
+	if ok, err := w.AppendIf(changed("up"), "up"); ok || err == nil {
+		t.Errorf("failed closed AppendIf test, expected an error, got %v err=%v", ok, err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	// append only if fewer than 3 elements are pending
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Do(func(tx *Tx[int]) {
+				if tx.Len() < 3 {
+					tx.Append(i)
+				}
+			})
+		}()
+	}
+	wg.Wait()
+	if l := w.Len(); l != 3 {
+		t.Errorf("failed Do test, expected 3 elements, got %d", l)
+	}
+
+	w.Do(func(tx *Tx[int]) {
+		tx.Discard(2)
+		if tx.Len() != 1 {
+			t.Errorf("failed Discard test, expected 1 element, got %d", tx.Len())
+		}
+		tx.Append(42)
+		if v, ok := tx.Last(); v != 42 || !ok {
+			t.Errorf("failed Last test, expected 42, got %d ok=%v", v, ok)
+		}
+		tx.Discard(10)
+		if _, ok := tx.Last(); ok {
+			t.Errorf("failed Last test, expected empty buffer")
+		}
+	})
+}
+
+func TestDoPinned(t *testing.T) {
+	w, err := New[int](4, WithFullPolicy(Block))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3)
+	w.Do(func(tx *Tx[int]) {
+		if n, err := tx.Append(4, 5); n != 0 || err != ErrBufferFull {
+			t.Errorf("failed pinned Append test, expected ErrBufferFull, got %d err=%v", n, err)
+		}
+		if n, err := tx.Append(4); n != 1 || err != nil {
+			t.Errorf("failed pinned Append test, expected 1, got %d err=%v", n, err)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package ringslice
+
+import "io"
+
+// unitFramer tracks units written by Writer.WriteUnit, storing the length of
+// each unit alongside its first element.
+type unitFramer[T any] struct {
+	w    *Writer[T]
+	lens storage[int64]
+}
+
+func (f *unitFramer[T]) align(pos int64) {
+	w := f.w
+	head := w.head.Load()
+	for w.msgTail < pos && w.msgTail < head {
+		w.msgTail += f.lens.at(w.msgTail)
+	}
+}
+
+// WriteUnit appends values to the buffer as a single unit. Readers using
+// ReadUnit will receive exactly the slices that were written, never split or
+// merged, and will skip any unit that was partially overwritten. Writing an
+// empty unit has no effect. A buffer used for units cannot be written to by
+// other means: such writes return ErrMixedWrites, as does WriteUnit on a
+// buffer holding data written otherwise.
+func (w *Writer[T]) WriteUnit(values []T) (int, error) {
+	n := int64(len(values))
+	if n > w.size {
+		return 0, ErrMsgTooLarge
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.framed(true); err != nil {
+		return 0, err
+	}
+	if err := w.reserve(int(n)); err != nil {
+		return 0, err
+	}
+	w.writes++
+	w.wsizes.add(int(n))
+
+	if w.framer == nil {
+		pageSize := int64(0)
+		if w.data.lazy {
+			pageSize = w.data.pageSize
+		}
+		w.framer = &unitFramer[T]{w: w, lens: newStorage[int64](w.size, pageSize)}
+		w.msgTail = w.head.Load()
+	}
+	f := w.framer.(*unitFramer[T])
+	head := w.head.Load()
+	f.align(head + n - w.size)
+	f.lens.set(head, n)
+	w.write(values)
+	if h := w.instrument(); h != nil {
+		h.OnWrite(len(values))
+	}
+
+	w.broadcast()
+	return int(n), nil
+}
+
+// ReadUnit reads the next unit written by Writer.WriteUnit. If no unit is
+// available, ReadUnit either returns io.EOF or blocks if the reader is
+// blocking. ReadUnit must not be mixed with other read methods on the same
+// reader.
+func (r *Reader[T]) ReadUnit() ([]T, error) {
+	if r.isClosed() {
+		return nil, io.ErrClosedPipe
+	}
+
+	w := r.w
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	head, err := r.wait()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.seekMsg(head); err != nil {
+		return nil, err
+	}
+
+	f, ok := w.framer.(*unitFramer[T])
+	if !ok {
+		return nil, errBadFrame
+	}
+
+	pos := r.pos.Load()
+	res := make([]T, f.lens.at(pos))
+	w.data.copyOut(res, pos)
+	r.pos.Store(pos + int64(len(res)))
+	r.release()
+	w.rsizes.add(len(res))
+	if h := w.instrument(); h != nil {
+		h.OnRead(r.id, len(res))
+	}
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestUnit(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+
+	w.WriteUnit([]int{1, 2, 3})
+	w.WriteUnit([]int{4})
+
+	u, err := r.ReadUnit()
+	if !slices.Equal(u, []int{1, 2, 3}) || err != nil {
+		t.Errorf("failed ReadUnit test, expected [1 2 3], got %v err=%v", u, err)
+	}
+
+	// partially overwrites the first unit
+	w.WriteUnit([]int{5, 6, 7})
+	w.WriteUnit([]int{8, 9, 10})
+
+	r2 := w.Reader()
+	for _, expect := range [][]int{{4}, {5, 6, 7}, {8, 9, 10}} {
+		u, err = r2.ReadUnit()
+		if !slices.Equal(u, expect) || err != nil {
+			t.Errorf("failed ReadUnit after wrap test, expected %v, got %v err=%v", expect, u, err)
+		}
+	}
+
+	_, err = r2.ReadUnit()
+	if err != io.EOF {
+		t.Errorf("failed ReadUnit EOF test, expected io.EOF, got err=%v", err)
+	}
+}
+
+func TestUnitMixed(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.WriteUnit([]int{1, 2})
+	if _, err := w.Append(3); err != ErrMixedWrites {
+		t.Errorf("failed mixed write test, expected ErrMixedWrites, got %v", err)
+	}
+	if _, err := w.WriteVec([][]int{{3}, {4}}); err != ErrMixedWrites {
+		t.Errorf("failed mixed WriteVec test, expected ErrMixedWrites, got %v", err)
+	}
+
+	w, _ = New[int](8)
+	w.Append(1)
+	if _, err := w.WriteUnit([]int{2}); err != ErrMixedWrites {
+		t.Errorf("failed mixed unit test, expected ErrMixedWrites, got %v", err)
+	}
+
+	b, _ := NewByteRing(16)
+	b.WriteUnit([]byte("ab"))
+	if err := b.WriteMsg([]byte("cd")); err != ErrMixedWrites {
+		t.Errorf("failed mixed message test, expected ErrMixedWrites, got %v", err)
+	}
+}
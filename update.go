@@ -0,0 +1,48 @@
+package ringslice
+
+import "runtime"
+
+// WriteAt replaces the element at absolute position seq, which must still be
+// retained in the buffer, or returns ErrRangeNotRetained. Readers which
+// already read the element are not notified.
+func (w *Writer[T]) WriteAt(seq int64, v T) error {
+	return w.modify(seq, func(T) T { return v })
+}
+
+// Update replaces the element at absolute position seq with the value
+// returned by fn, called with the current value while the buffer is locked,
+// so concurrent updates of an element do not race. Like WriteAt, it returns
+// ErrRangeNotRetained if seq is not retained in the buffer.
+func (w *Writer[T]) Update(seq int64, fn func(T) T) error {
+	return w.modify(seq, fn)
+}
+
+// modify implements WriteAt and Update.
+func (w *Writer[T]) modify(seq int64, fn func(T) T) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.reserve(0); err != nil {
+		return err
+	}
+	head := w.head.Load()
+	if seq < w.oldest(head) || seq >= head {
+		return ErrRangeNotRetained
+	}
+
+	w.exclusive()
+	defer w.inplace.Store(false)
+	w.data.set(seq, fn(w.data.at(seq)))
+	w.verify()
+	return nil
+}
+
+// exclusive prevents lock-free reads while retained data is modified in
+// place, and waits for a lock-free read in progress to complete. The caller
+// must hold the lock, and reset inplace once done.
+func (w *Writer[T]) exclusive() {
+	w.inplace.Store(true)
+	for w.claim.Load() != 0 {
+		runtime.Gosched()
+	}
+}
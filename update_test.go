@@ -0,0 +1,96 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestWriteAt(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append(1, 2, 3, 4, 5)
+	if err := w.WriteAt(3, 40); err != nil {
+		t.Errorf("failed WriteAt test, expected nil, got %v", err)
+	}
+	if s := w.Snapshot(); !slices.Equal(s, []int{2, 3, 40, 5}) {
+		t.Errorf("failed WriteAt test, expected [2 3 40 5], got %v", s)
+	}
+	for _, seq := range []int64{0, 5, -1} {
+		if err := w.WriteAt(seq, 0); err != ErrRangeNotRetained {
+			t.Errorf("failed WriteAt %d test, expected ErrRangeNotRetained, got %v", seq, err)
+		}
+	}
+
+	w.Truncate(1)
+	if err := w.WriteAt(3, 0); err != ErrRangeNotRetained {
+		t.Errorf("failed truncated WriteAt test, expected ErrRangeNotRetained, got %v", err)
+	}
+	w.Close()
+	if err := w.WriteAt(4, 0); err != io.ErrClosedPipe {
+		t.Errorf("failed closed WriteAt test, expected io.ErrClosedPipe, got %v", err)
+	}
+}
+
+func TestWriteAtConcurrent(t *testing.T) {
+	w, err := New[int](16)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]int, 4)
+		for {
+			if _, err := r.Read(buf); err != nil && err != io.EOF {
+				return
+			}
+			if r.Closed() {
+				return
+			}
+		}
+	}()
+
+	for i := range 1000 {
+		w.Append(i)
+		w.WriteAt(int64(i), -i)
+	}
+	r.Close()
+	<-done
+}
+
+func TestUpdate(t *testing.T) {
+	w, err := New[int](4)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	w.Append(0, 0)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				w.Update(1, func(v int) int { return v + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s := w.Snapshot(); !slices.Equal(s, []int{0, 1000}) {
+		t.Errorf("failed Update test, expected [0 1000], got %v", s)
+	}
+	if err := w.Update(2, func(v int) int { return v }); err != ErrRangeNotRetained {
+		t.Errorf("failed Update test, expected ErrRangeNotRetained, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package ringslice
+
+import "io"
+
+// ReadVec reads data into the slices of bufs in order, filling each one
+// before moving to the next, while locking the buffer once. It blocks like
+// Read until at least one element is available if the reader is blocking,
+// and returns the total number of elements read.
+func (r *Reader[T]) ReadVec(bufs [][]T) (int, error) {
+	if r.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+	if len(r.ahead) > 0 || r.limit != nil || r.w.frame > 1 {
+		// rate limits, frames and read-ahead are handled by Read
+		return r.readVecSlow(bufs)
+	}
+
+	w := r.w
+	w.mutex.RLock()
+	total := 0
+	var err error
+	for _, p := range bufs {
+		if len(p) == 0 {
+			continue
+		}
+		var n int
+		n, err = r.fetchLocked(p, total > 0)
+		total += n
+		if err != nil || n < len(p) {
+			break
+		}
+	}
+	w.mutex.RUnlock()
+
+	if total == 0 {
+		return 0, err
+	}
+	w.rsizes.add(total)
+	if h := w.instrument(); h != nil {
+		h.OnRead(r.id, total)
+	}
+	return total, nil
+}
+
+// readVecSlow implements ReadVec with successive reads.
+func (r *Reader[T]) readVecSlow(bufs [][]T) (int, error) {
+	total := 0
+	for _, p := range bufs {
+		if len(p) == 0 {
+			continue
+		}
+		if total > 0 && r.Lag() == 0 && len(r.ahead) == 0 {
+			// do not block once some data was read
+			break
+		}
+		n, err := r.Read(p)
+		total += n
+		if err != nil {
+			if total > 0 {
+				return total, nil
+			}
+			return 0, err
+		}
+		if n < len(p) {
+			break
+		}
+	}
+	return total, nil
+}
+
+// WriteVec writes the concatenation of the slices of bufs while locking the
+// buffer once, making the data visible to readers at once unless the write
+// has to wait for pinned readers to make room.
+func (w *Writer[T]) WriteVec(bufs [][]T) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	n := 0
+	for _, buf := range bufs {
+		n += len(buf)
+	}
+	unit := w.unit()
+	if w.framer != nil || w.collapse != nil || w.free(n) < n || n%unit != 0 {
+		// let writeAll handle waits and errors
+		return w.writeAll(concat(bufs, n))
+	}
+	if err := w.reserve(min(n, unit)); err != nil {
+		return 0, err
+	}
+	w.writes++
+	w.wsizes.add(n)
+
+	w.writeVec(bufs)
+	if h := w.instrument(); h != nil {
+		h.OnWrite(n)
+	}
+	w.broadcast()
+	return n, nil
+}
+
+// concat returns the concatenation of the n elements of bufs.
+func concat[T any](bufs [][]T, n int) []T {
+	res := make([]T, 0, n)
+	for _, buf := range bufs {
+		res = append(res, buf...)
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package ringslice
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestReadVec(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+	r := w.BlockingReader()
+	defer r.Close()
+
+	w.Append(1, 2, 3, 4, 5)
+
+	a, b, c := make([]int, 2), make([]int, 0), make([]int, 4)
+	n, err := r.ReadVec([][]int{a, b, c})
+	if n != 5 || err != nil || !slices.Equal(a, []int{1, 2}) || !slices.Equal(c[:3], []int{3, 4, 5}) {
+		t.Errorf("failed read vec test, got %d %v %v err=%v", n, a, c, err)
+	}
+
+	w.Append(6)
+	w.closeWithError(nil)
+	n, err = r.ReadVec([][]int{a, c})
+	if n != 1 || err != nil || a[0] != 6 {
+		t.Errorf("failed read vec test, expected [6], got %d %v err=%v", n, a, err)
+	}
+	if _, err := r.ReadVec([][]int{a}); err != io.EOF {
+		t.Errorf("failed read vec eof test, expected io.EOF, got %v", err)
+	}
+
+	// read-ahead fallback
+	w, _ = New[int](8)
+	r = w.Reader()
+	defer r.Close()
+	w.Append(1, 2, 3)
+	r.ReadOne()
+	n, _ = r.ReadVec([][]int{a[:1], c})
+	if n != 2 || a[0] != 2 || c[0] != 3 {
+		t.Errorf("failed read vec fallback test, got %d %v %v", n, a, c)
+	}
+}
+
+func TestWriteVec(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+
+	if n, err := w.WriteVec([][]int{{1, 2}, nil, {3}}); n != 3 || err != nil {
+		t.Errorf("failed WriteVec test, expected 3, got %d err=%v", n, err)
+	}
+	// larger than the buffer, only the last part is kept
+	if n, err := w.WriteVec([][]int{{4, 5, 6, 7}, {8, 9, 10, 11}, {12}}); n != 9 || err != nil {
+		t.Errorf("failed WriteVec test, expected 9, got %d err=%v", n, err)
+	}
+	if s := w.Snapshot(); !slices.Equal(s, []int{5, 6, 7, 8, 9, 10, 11, 12}) {
+		t.Errorf("failed WriteVec test, expected [5 .. 12], got %v", s)
+	}
+	if s := w.Stats(); s.Writes != 2 || s.Elements != 12 {
+		t.Errorf("failed WriteVec stats test, expected 2 writes of 12 elements, got %+v", s)
+	}
+
+	w.closeWithError(nil)
+	if _, err := w.WriteVec([][]int{{1}}); err != io.ErrClosedPipe {
+		t.Errorf("failed closed WriteVec test, expected io.ErrClosedPipe, got %v", err)
+	}
+}
+
+func TestWriteVecPinned(t *testing.T) {
+	w, err := New[int](4, WithFullPolicy(Reject))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	if n, err := w.WriteVec([][]int{{1, 2}, {3}}); n != 3 || err != nil {
+		t.Errorf("failed WriteVec test, expected 3, got %d err=%v", n, err)
+	}
+	if _, err := w.WriteVec([][]int{{4}, {5}}); err != ErrBufferFull {
+		t.Errorf("failed pinned WriteVec test, expected ErrBufferFull, got %v", err)
+	}
+}
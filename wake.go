@@ -0,0 +1,105 @@
+package ringslice
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// wakeLevel holds the readers of a given wake priority waiting for data.
+type wakeLevel struct {
+	prio    int
+	cond    *sync.Cond
+	waiting atomic.Int32 // readers waiting, or woken and not resumed yet
+}
+
+// SetWakePriority sets the priority of the reader when waking blocked
+// readers: when data becomes available, readers with a higher priority are
+// woken first, and readers of a lower priority are only woken once a reader
+// of a higher priority has resumed, so latency-critical readers get scheduled
+// first. Readers have a priority of zero by default, and negative priorities
+// can be used for best-effort readers. Readers of the same priority are woken
+// together.
+func (r *Reader[T]) SetWakePriority(prio int) {
+	w := r.w
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if prio == r.prio {
+		return
+	}
+	// move a blocked read to the new priority
+	r.wakeLevel().cond.Broadcast()
+	r.prio = prio
+	r.level = w.levelFor(prio)
+}
+
+// WakePriority returns the wake priority of the reader, see SetWakePriority.
+func (r *Reader[T]) WakePriority() int {
+	r.w.mutex.RLock()
+	defer r.w.mutex.RUnlock()
+
+	return r.prio
+}
+
+// wakeLevel returns the level the reader waits on for data. The caller must
+// hold the lock.
+func (r *Reader[T]) wakeLevel() *wakeLevel {
+	if r.level != nil {
+		return r.level
+	}
+	return r.w.levels[r.w.zero]
+}
+
+// sleep waits for the reader to be woken, then wakes the readers of the next
+// lower priority. The caller must hold the read lock.
+func (r *Reader[T]) sleep() {
+	l := r.wakeLevel()
+	l.waiting.Add(1)
+	l.cond.Wait()
+	r.w.wakeBelow(l.prio)
+	l.waiting.Add(-1)
+}
+
+// levelFor returns the level of readers of priority prio, creating it if
+// needed. The caller must hold the lock.
+func (w *Writer[T]) levelFor(prio int) *wakeLevel {
+	i, found := slices.BinarySearchFunc(w.levels, prio, func(l *wakeLevel, prio int) int {
+		// levels are sorted by decreasing priority
+		return prio - l.prio
+	})
+	if !found {
+		w.levels = slices.Insert(w.levels, i, &wakeLevel{prio: prio, cond: sync.NewCond(w.mutex.RLocker())})
+		if prio > 0 {
+			w.zero++
+		}
+	}
+	return w.levels[i]
+}
+
+// broadcast wakes the readers of the highest priority waiting for data,
+// which wake the lower priorities in turn once they resume. The caller must
+// hold the lock.
+func (w *Writer[T]) broadcast() {
+	if len(w.levels) == 1 {
+		w.cond.Broadcast()
+		return
+	}
+	for _, l := range w.levels {
+		if l.waiting.Load() > 0 {
+			l.cond.Broadcast()
+			return
+		}
+	}
+}
+
+// wakeBelow wakes the readers of the highest priority lower than prio
+// waiting for data. The caller must hold the lock.
+func (w *Writer[T]) wakeBelow(prio int) {
+	for _, l := range w.levels {
+		if l.prio < prio && l.waiting.Load() > 0 {
+			l.cond.Broadcast()
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ringslice
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWakePriority(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	prios := []int{-1, 5, 0, 2, 5}
+	var wg sync.WaitGroup
+	readers := make([]*Reader[int], len(prios))
+	for i, prio := range prios {
+		r := w.BlockingReader()
+		r.SetWakePriority(prio)
+		if r.WakePriority() != prio {
+			t.Errorf("failed wake priority test, expected %d, got %d", prio, r.WakePriority())
+		}
+		readers[i] = r
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := r.ReadOne(); v != 42 || err != nil {
+				t.Errorf("failed wake priority test, expected 42, got %d err=%v", v, err)
+			}
+		}()
+	}
+
+	if len(w.levels) != 4 || w.levels[0].prio != 5 || w.levels[1].prio != 2 || w.levels[w.zero].prio != 0 || w.levels[3].prio != -1 {
+		t.Errorf("failed wake priority test, levels not sorted")
+	}
+
+	// change the priority of a blocked reader
+	for w.Stats().Blocked < len(prios) {
+		time.Sleep(time.Millisecond)
+	}
+	readers[0].SetWakePriority(1)
+
+	w.Append(42)
+	wg.Wait()
+	for _, r := range readers {
+		r.Close()
+	}
+}
+
+func TestWakePriorityOrder(t *testing.T) {
+	w, err := New[int](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	low := w.BlockingReader()
+	defer low.Close()
+	low.SetWakePriority(-1)
+
+	done := make(chan struct{})
+	go func() {
+		low.ReadOne()
+		close(done)
+	}()
+	for w.Stats().Blocked < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// a reader of a higher priority was woken but did not resume yet
+	w.mutex.Lock()
+	high := w.levelFor(1)
+	high.waiting.Add(1)
+	w.mutex.Unlock()
+
+	w.Append(1)
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-done:
+		t.Errorf("failed wake priority order test, low priority reader woken first")
+	default:
+	}
+
+	// the higher priority reader resumes
+	w.mutex.RLock()
+	w.wakeBelow(high.prio)
+	high.waiting.Add(-1)
+	w.mutex.RUnlock()
+	<-done
+}
@@ -0,0 +1,74 @@
+package ringslice
+
+import "errors"
+
+var errWatermarks = errors.New("Low watermark must not exceed the high watermark")
+
+// Watermarks holds callbacks called when the depth of the buffer, which is
+// the number of elements the slowest reader has not read yet, crosses
+// thresholds. This allows producers to throttle before readers go stale.
+//
+// Like Hooks, callbacks may be called while the buffer is locked and must not
+// call methods of the buffer or its readers. Nil callbacks are ignored.
+type Watermarks struct {
+	Low  int64
+	High int64
+
+	// OnHigh is called when the depth reaches High.
+	OnHigh func(depth int64)
+	// OnLow is called when the depth falls back to Low after OnHigh was
+	// called.
+	OnLow func(depth int64)
+}
+
+// SetWatermarks sets the watermarks of the buffer, or disables them if m is
+// nil. It returns an error if m.Low is larger than m.High.
+func (w *Writer[T]) SetWatermarks(m *Watermarks) error {
+	if m != nil {
+		if m.Low > m.High {
+			return errWatermarks
+		}
+		c := *m
+		m = &c
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.marks.Store(m)
+	w.above.Store(false)
+	w.checkWatermarks()
+	return nil
+}
+
+// depth returns the number of elements the slowest reader has not read yet.
+// The caller must hold the lock.
+func (w *Writer[T]) depth() int64 {
+	head := w.head.Load()
+	slowest := head
+	for r := range w.active {
+		slowest = min(slowest, r.pos.Load())
+	}
+	return head - slowest
+}
+
+// checkWatermarks calls the watermark callbacks if the depth of the buffer
+// crossed a watermark. The caller must hold the lock.
+func (w *Writer[T]) checkWatermarks() {
+	m := w.marks.Load()
+	if m == nil {
+		return
+	}
+
+	depth := w.depth()
+	switch {
+	case depth >= m.High:
+		if w.above.CompareAndSwap(false, true) && m.OnHigh != nil {
+			m.OnHigh(depth)
+		}
+	case depth <= m.Low:
+		if w.above.CompareAndSwap(true, false) && m.OnLow != nil {
+			m.OnLow(depth)
+		}
+	}
+}
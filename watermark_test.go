@@ -0,0 +1,68 @@
+package ringslice
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWatermarks(t *testing.T) {
+	var events []int64
+	w, err := New[int](16, WithWatermarks(Watermarks{
+		Low:    2,
+		High:   8,
+		OnHigh: func(depth int64) { events = append(events, depth) },
+		OnLow:  func(depth int64) { events = append(events, -depth) },
+	}))
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	r := w.Reader()
+	defer r.Close()
+	fast := w.Reader()
+	defer fast.Close()
+	buf := make([]int, 16)
+
+	w.Write(make([]int, 6))
+	fast.Read(buf)
+	w.Write(make([]int, 3)) // depth 9 for r
+	w.Write(make([]int, 1))
+	r.Read(buf[:5]) // depth 5
+	r.Read(buf[:4]) // depth 4 for fast
+	fast.Read(buf)  // depth 1
+	w.Write(make([]int, 1))
+
+	if !slices.Equal(events, []int64{9, -1}) {
+		t.Errorf("failed watermarks test, expected [9 -1], got %v", events)
+	}
+
+	if err := w.SetWatermarks(&Watermarks{Low: 3, High: 2}); err == nil {
+		t.Errorf("failed invalid watermarks test, expected an error")
+	}
+	if _, err := New[int](4, WithWatermarks(Watermarks{Low: 3, High: 2})); err == nil {
+		t.Errorf("failed invalid watermarks test, expected an error")
+	}
+
+	// callbacks are called as soon as watermarks are set
+	events = nil
+	w.SetWatermarks(&Watermarks{
+		Low:    0,
+		High:   2,
+		OnHigh: func(depth int64) { events = append(events, depth) },
+		OnLow:  func(depth int64) { events = append(events, -depth) },
+	})
+	if !slices.Equal(events, []int64{2}) {
+		t.Errorf("failed watermarks test, expected [2], got %v", events)
+	}
+	fast.Close()
+	r.Reset()
+	if !slices.Equal(events, []int64{2, 0}) {
+		t.Errorf("failed watermarks test, expected [2 0], got %v", events)
+	}
+	w.SetWatermarks(nil)
+	w.Write(make([]int, 4))
+	if len(events) != 2 {
+		t.Errorf("failed disabled watermarks test, got %v", events)
+	}
+}
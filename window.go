@@ -0,0 +1,76 @@
+package ringslice
+
+import "time"
+
+// Aggregate holds statistics computed over a set of numeric elements. Sum is
+// computed in T and wraps on overflow like any arithmetic on T, while Mean is
+// computed in float64 and does not.
+type Aggregate[T Number] struct {
+	Count int64
+	Sum   T
+	Min   T
+	Max   T
+	Mean  float64
+}
+
+// WindowStats computes statistics over the elements of w written during the
+// last d, which requires timestamps to be enabled (see SetTimestamps). The
+// statistics are computed in place while w is locked for reading, without
+// copying the elements. Min, Max and Mean are zero if the window is empty.
+func WindowStats[T Number](w *Writer[T], d time.Duration) (Aggregate[T], error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	start, err := w.searchTime(w.clk().Now().Add(-d))
+	if err != nil {
+		return Aggregate[T]{}, err
+	}
+	return aggregate(w, start), nil
+}
+
+// aggregate computes statistics over the live elements of w from position
+// start. The caller must hold the read lock.
+func aggregate[T Number](w *Writer[T], start int64) Aggregate[T] {
+	var res Aggregate[T]
+	var sum float64 // for Mean, as res.Sum may overflow
+	w.each(start, func(_ int64, v T) bool {
+		if res.Count == 0 || v < res.Min {
+			res.Min = v
+		}
+		if res.Count == 0 || v > res.Max {
+			res.Max = v
+		}
+		res.Sum += v
+		sum += float64(v)
+		res.Count++
+		return true
+	})
+
+	if res.Count > 0 {
+		res.Mean = sum / float64(res.Count)
+	}
+	return res
+}
+
+// each calls fn in order for the position and value of every live element of
+// the buffer from position start, without copying them, until fn returns
+// false. The caller must hold the read lock.
+func (w *Writer[T]) each(start int64, fn func(int64, T) bool) {
+	head := w.head.Load()
+	start = max(start, w.live(head))
+	now := w.clk().Now().UnixNano()
+
+	w.data.segments(start, head-start, func(seg []T) error {
+		for i, v := range seg {
+			pos := start + int64(i)
+			if w.expired(pos, now) {
+				continue
+			}
+			if !fn(pos, v) {
+				return errStop
+			}
+		}
+		start += int64(len(seg))
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+package ringslice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowStats(t *testing.T) {
+	w, err := New[float64](8)
+	if err != nil {
+		t.Errorf("failed to initialize buffer")
+		return
+	}
+
+	if _, err := WindowStats(w, time.Second); err != ErrNoTimestamps {
+		t.Errorf("failed window stats test, expected ErrNoTimestamps, got %v", err)
+	}
+
+	w.SetTimestamps(true)
+	w.Append(100, 200)
+	time.Sleep(30 * time.Millisecond)
+	w.Append(1, 5, 3)
+
+	res, err := WindowStats(w, 20*time.Millisecond)
+	if err != nil || res.Count != 3 || res.Sum != 9 || res.Min != 1 || res.Max != 5 || res.Mean != 3 {
+		t.Errorf("failed window stats test, got %+v err=%v", res, err)
+	}
+
+	res, _ = WindowStats(w, time.Hour)
+	if res.Count != 5 || res.Max != 200 {
+		t.Errorf("failed window stats full test, got %+v", res)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	res, _ = WindowStats(w, 20*time.Millisecond)
+	if res.Count != 0 || res.Mean != 0 {
+		t.Errorf("failed window stats empty test, got %+v", res)
+	}
+}
@@ -3,114 +3,288 @@ package ringslice
 import (
 	"errors"
 	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/trace"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+var ErrInvalidSize = errors.New("ringbuffer size must be positive")
+
 // Writer is the main data container.
 type Writer[T any] struct {
-	data  []T
+	data  storage[T]
 	size  int64
-	wPos  int64 // write pos
-	cycle int64
-
-	closed bool
-	mutex  sync.RWMutex
-	cond   *sync.Cond
-	wg     sync.WaitGroup
+	frame int64        // writes and reads are multiples of frame, see NewFramed
+	head  atomic.Int64 // total number of elements written, write pos is head%size
+	tail  atomic.Int64 // data before this position was discarded by Truncate
+
+	// lock-free reader support: pending is the head position a write in
+	// progress will reach, claim is the position (+1) from which the only
+	// registered reader is currently copying data, or 0. inplace is set
+	// while retained data is modified, see exclusive
+	readers atomic.Int32
+	pending atomic.Int64
+	claim   atomic.Int64
+	inplace atomic.Bool
+
+	// message framing, if used: msgTail is the first message boundary
+	// still retained, maintained by framer
+	framer  framer
+	msgTail int64
+
+	// pinned readers, whose unread data must not be overwritten. Writes
+	// wait on space until pinned readers have made enough progress.
+	pins  map[*Reader[T]]struct{}
+	space *sync.Cond
+
+	// per-element timestamps in nanoseconds, if enabled, and the last
+	// timestamp recorded
+	times    *storage[int64]
+	lastTime int64
+	maxAge   atomic.Int64 // see SetMaxAge
+
+	// per-element expiration time in nanoseconds (0 for none), if enabled,
+	// the TTL of the write in progress and the function deriving TTLs
+	expires *storage[int64]
+	hasTTL  atomic.Bool
+	ttl     time.Duration
+	ttlFunc func(T) time.Duration
+
+	// equality of consecutive elements collapsed by writes and the number
+	// of writes of each element, see SetCollapse
+	collapse func(a, b T) bool
+	repeats  *storage[int64]
+
+	// defaults for new readers and full buffer policy, see New
+	blocking  bool
+	autoSkip  bool
+	strictEOF bool
+	full      FullPolicy
+
+	// consumer groups by name, see ConsumerGroup
+	groups map[string]*ConsumerGroup[T]
+
+	// channels notified after each write
+	watchers []chan<- struct{}
+
+	// functions called with the lock held after each write, with the
+	// range of positions written, see MovingAverage
+	observers []*func(start, end int64)
+
+	// statistics, see Stats
+	active      map[*Reader[T]]struct{} // open readers
+	lastID      uint64                  // last reader ID assigned
+	blocked     atomic.Int32            // readers waiting for data
+	writes      int64                   // number of write calls
+	overwritten int64                   // elements lost before being read
+	wsizes      sizeCounter             // sizes of write calls
+	rsizes      sizeCounter             // sizes of read calls
+	leakCheck   bool                    // record reader creation stacks
+
+	// event reporting
+	instr   atomic.Pointer[Instrumentation] // see SetInstrumentation
+	hooks   atomic.Pointer[Hooks]           // see SetHooks
+	logger  atomic.Pointer[slog.Logger]     // see SetLogger
+	clock   atomic.Pointer[Clock]           // see SetClock
+	commit  atomic.Pointer[CommitHook]      // see SetCommitHook
+	tracing atomic.Bool                     // see SetTracing
+	marks   atomic.Pointer[Watermarks]      // see SetWatermarks
+	above   atomic.Bool                     // depth reached the high watermark
+
+	// strict mode, and the last head position checked, see SetStrict
+	strict  atomic.Bool
+	checked atomic.Int64
+
+	closed    bool
+	paused    bool      // writes wait for Resume, see Pause
+	closeErr  error     // returned by readers instead of io.EOF once closed
+	werr      error     // returned by writes, if set
+	wdeadline time.Time // deadline for writes waiting on pinned readers
+	mutex     sync.RWMutex
+	cond      *sync.Cond   // readers of wake priority zero waiting for data
+	levels    []*wakeLevel // wake priorities by decreasing priority, see SetWakePriority
+	zero      int          // index of wake priority zero in levels
+	wg        sync.WaitGroup
 }
 
-func New[T any](size int64) (*Writer[T], error) {
+// New returns a new Writer holding up to size elements, configured by opts.
+func New[T any](size int64, opts ...Option) (*Writer[T], error) {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
 	if size <= 0 {
-		return nil, errors.New("Size must be positive")
+		return nil, ErrInvalidSize
+	}
+	if c.marks != nil && c.marks.Low > c.marks.High {
+		return nil, errWatermarks
 	}
+	if c.frameSize < 0 {
+		return nil, errors.New("Frame size must be positive")
+	}
+	if size%max(c.frameSize, 1) != 0 {
+		return nil, errors.New("Size must be a multiple of the frame size")
+	}
+
+	w := &Writer[T]{}
+	w.init(size, c.pageSize)
+	w.frame = c.frameSize
+	w.blocking = c.blocking
+	w.autoSkip = c.autoSkip
+	w.strictEOF = c.strictEOF
+	w.full = c.full
 
-	w := &Writer[T]{
-		data: make([]T, size),
-		size: size,
+	if c.timestamps || c.maxAge > 0 {
+		w.enableTimestamps()
 	}
-	w.cond = sync.NewCond(w.mutex.RLocker())
+	w.maxAge.Store(int64(max(c.maxAge, 0)))
+	if c.hooks != nil {
+		w.hooks.Store(c.hooks)
+	}
+	w.SetInstrumentation(c.instr)
+	w.SetLogger(c.logger)
+	w.SetClock(c.clock)
+	w.leakCheck = c.leakCheck
+	w.strict.Store(c.strict)
+	w.marks.Store(c.marks)
 
 	return w, nil
 }
 
+// NewChunked returns a new Writer whose storage is split in pages of
+// pageSize elements, which are only allocated when first written to and can
+// be released by Truncate. This avoids a single huge allocation for very
+// large buffers. A pageSize of zero (or larger than size) is the same as New.
+func NewChunked[T any](size, pageSize int64) (*Writer[T], error) {
+	return New[T](size, WithPageSize(pageSize))
+}
+
+// init initializes a zero Writer.
+func (w *Writer[T]) init(size, pageSize int64) {
+	w.data = newStorage[T](size, pageSize)
+	w.size = size
+	w.cond = sync.NewCond(w.mutex.RLocker())
+	w.levels = []*wakeLevel{{cond: w.cond}}
+	w.space = sync.NewCond(&w.mutex)
+}
+
+// NewReader is like Reader, but returns ErrWriterClosed instead of a nil
+// reader if the writer is closed.
+func (w *Writer[T]) NewReader() (*Reader[T], error) {
+	return w.newReaderErr(w.blocking, false)
+}
+
+// NewBlockingReader is like BlockingReader, but returns ErrWriterClosed
+// instead of a nil reader if the writer is closed.
+func (w *Writer[T]) NewBlockingReader() (*Reader[T], error) {
+	return w.newReaderErr(true, false)
+}
+
+// NewBlockingCurrentReader is like BlockingCurrentReader, but returns
+// ErrWriterClosed instead of a nil reader if the writer is closed.
+func (w *Writer[T]) NewBlockingCurrentReader() (*Reader[T], error) {
+	return w.newReaderErr(true, true)
+}
+
+// newReaderErr is like newReader, but returns ErrWriterClosed if the writer
+// is closed.
+func (w *Writer[T]) newReaderErr(block, current bool) (*Reader[T], error) {
+	if r := w.newReader(block, current); r != nil {
+		return r, nil
+	}
+	return nil, ErrWriterClosed
+}
+
 // Reader returns a new reader positioned at the buffer's oldest available
 // position. The reader can be moved to the most recent position by calling
 // its Reset() method.
 //
 // If there isn't enough data to read, the reader's read method will return
 // error io.EOF. If you need Read() to not return until new data is available,
-// use BlockingReader()
+// use BlockingReader(), or create the writer with WithBlocking. Reader returns
+// nil if the writer is closed, see NewReader.
 func (w *Writer[T]) Reader() *Reader[T] {
-	if w.closed {
-		return nil
-	}
-
-	cycle := w.cycle
-	pos := w.wPos
-	// rewind
-	if cycle > 0 {
-		cycle = cycle - 1
-	} else {
-		pos = 0
-	}
-
-	w.wg.Add(1)
-
-	return &Reader[T]{
-		w:      w,
-		block:  false,
-		cycle:  cycle,
-		rPos:   pos,
-		closed: new(uint64),
-	}
+	return w.newReader(w.blocking, false)
 }
 
 // BlockingReader returns a new reader positioned at the buffer's oldest
-// available position which reads will block if no new data is available.
+// available position which reads will block if no new data is available. It
+// returns nil if the writer is closed, see NewBlockingReader.
 func (w *Writer[T]) BlockingReader() *Reader[T] {
-	if w.closed {
-		return nil
-	}
+	return w.newReader(true, false)
+}
 
-	cycle := w.cycle
-	pos := w.wPos
-	// rewind
-	if cycle > 0 {
-		cycle = cycle - 1
-	} else {
-		pos = 0
-	}
+// BlockingCurrentReader returns a new reader positionned at the buffer's
+// edge. It returns nil if the writer is closed, see
+// NewBlockingCurrentReader.
+func (w *Writer[T]) BlockingCurrentReader() *Reader[T] {
+	return w.newReader(true, true)
+}
 
-	w.wg.Add(1)
+// newReader registers a new reader on the writer, positioned either at the
+// oldest available position or at the current edge of the buffer, using the
+// reader defaults set by options.
+func (w *Writer[T]) newReader(block, current bool) *Reader[T] {
+	return w.register(block, current, true)
+}
 
-	return &Reader[T]{
-		w:      w,
-		block:  true,
-		cycle:  cycle,
-		rPos:   pos,
-		closed: new(uint64),
-	}
+// internalReader is like newReader, for readers used internally by helpers
+// of the package, which ignore the reader defaults set by options and are
+// never pinned by the full policy.
+func (w *Writer[T]) internalReader(block, current bool) *Reader[T] {
+	return w.register(block, current, false)
 }
 
-// BlockingCurrentReader returns a new reader positionned at the buffer's
-// edge.
-func (w *Writer[T]) BlockingCurrentReader() *Reader[T] {
+// register implements newReader and internalReader.
+func (w *Writer[T]) register(block, current, defaults bool) *Reader[T] {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
 	if w.closed {
 		return nil
 	}
 
-	cycle := w.cycle
-	pos := w.wPos
+	pos := w.head.Load()
+	if !current {
+		// rewind
+		pos = w.oldest(pos)
+	}
 
 	w.wg.Add(1)
+	w.readers.Add(1)
 
-	return &Reader[T]{
-		w:      w,
-		block:  true,
-		cycle:  cycle,
-		rPos:   pos,
-		closed: new(uint64),
+	r := &Reader[T]{
+		w:         w,
+		block:     block,
+		closed:    new(uint64),
+		readAhead: defaultReadAhead,
+	}
+	r.pos.Store(pos)
+	if defaults {
+		r.autoSkip.Store(w.autoSkip)
+		r.strict = w.strictEOF
+		if w.full != Overwrite {
+			w.pin(r)
+		}
+	}
+
+	w.lastID++
+	r.id = w.lastID
+	if w.leakCheck {
+		r.stack = stack()
+	}
+	if w.active == nil {
+		w.active = make(map[*Reader[T]]struct{})
 	}
+	w.active[r] = struct{}{}
+	w.checkWatermarks()
+	return r
 }
 
 // Append values to the slice
@@ -119,49 +293,355 @@ func (w *Writer[T]) Append(values ...T) (int, error) {
 }
 
 func (w *Writer[T]) Write(values []T) (int, error) {
-	n := int64(len(values))
-
 	// lock buffer while writing
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
-	if w.closed {
-		return 0, io.ErrClosedPipe
+	return w.writeAll(values)
+}
+
+// writeAll writes values, waiting for pinned readers as needed. The caller
+// must hold the lock.
+func (w *Writer[T]) writeAll(values []T) (int, error) {
+	if w.framer != nil {
+		return 0, ErrMixedWrites
 	}
+	unit := w.unit()
+	if len(values)%unit != 0 {
+		return 0, ErrFrameSize
+	}
+	if w.full == Reject && w.free(len(values)) < len(values) {
+		return 0, ErrBufferFull
+	}
+	if w.collapse != nil {
+		return w.writeCollapsed(values)
+	}
+	if err := w.reserve(min(len(values), unit)); err != nil {
+		return 0, err
+	}
+	w.writes++
+	w.wsizes.add(len(values))
+
+	n := 0
+	for {
+		c := w.free(len(values) - n)
+		w.write(values[n : n+c])
+		n += c
+		if h := w.instrument(); h != nil {
+			h.OnWrite(c)
+		}
+
+		// wake readers
+		w.broadcast()
+
+		if n >= len(values) {
+			return n, nil
+		}
+
+		// wait for pinned readers to make some room
+		if err := w.reserve(unit); err != nil {
+			return n, err
+		}
+	}
+}
+
+// reserve waits until n elements can be written at once, and returns an
+// error if the writer cannot be written to. The caller must hold the lock.
+func (w *Writer[T]) reserve(n int) error {
+	var timer Timer
+	var armed time.Time
+	var region *trace.Region
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+		endRegion(region)
+	}()
+
+	for {
+		if w.closed {
+			return io.ErrClosedPipe
+		}
+		if w.werr != nil {
+			return w.werr
+		}
+		if w.paused {
+			if w.full == Reject {
+				return ErrPaused
+			}
+		} else if w.free(n) >= n {
+			return nil
+		}
+		if !w.wdeadline.IsZero() {
+			d := w.wdeadline.Sub(w.clk().Now())
+			if d <= 0 {
+				return os.ErrDeadlineExceeded
+			}
+			if !armed.Equal(w.wdeadline) {
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = w.clk().AfterFunc(d, w.wake)
+				armed = w.wdeadline
+			}
+		}
+		if w.full == Reject {
+			return ErrBufferFull
+		}
+		if region == nil {
+			region = w.traceRegion("ringslice.WriteWait")
+		}
+		w.space.Wait()
+	}
+}
+
+// SetWriteDeadline sets the deadline for writes waiting for pinned readers
+// to make room in the buffer. Writes which would block past t return
+// os.ErrDeadlineExceeded instead. A zero value for t means writes will not
+// time out.
+func (w *Writer[T]) SetWriteDeadline(t time.Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.wdeadline = t
+	w.space.Broadcast()
+}
+
+// wake wakes all blocked readers and writers so they can check their state.
+func (w *Writer[T]) wake() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.broadcast()
+	w.space.Broadcast()
+}
 
-	if n > w.size {
-		// volume of written data is larger than our buffer (NOTE: will invalidate ALL existing readers)
-		cnt := n / w.size
-		w.cycle += cnt - 1
-		w.wPos += n % w.size
-		// only use relevant part of buf
-		values = values[n-w.size:]
+// free returns how many of the want elements can be written without
+// overwriting data pinned readers haven't read yet. The caller must hold the
+// lock.
+func (w *Writer[T]) free(want int) int {
+	if len(w.pins) == 0 {
+		return want
+	}
+
+	head := w.head.Load()
+	avail := w.size
+	for r := range w.pins {
+		avail = min(avail, w.size-(head-r.pos.Load()))
+	}
+	return int(w.floor(min(int64(want), max(avail, 0))))
+}
+
+// pin registers r as a pinned reader. The caller must hold the lock.
+func (w *Writer[T]) pin(r *Reader[T]) {
+	if w.pins == nil {
+		w.pins = make(map[*Reader[T]]struct{})
+	}
+	w.pins[r] = struct{}{}
+	r.pinned = true
+}
+
+// unpin removes r from pinned readers. The caller must hold the lock.
+func (w *Writer[T]) unpin(r *Reader[T]) {
+	delete(w.pins, r)
+	r.pinned = false
+	w.space.Broadcast()
+}
+
+// write stores values in the buffer. The caller must hold the lock.
+func (w *Writer[T]) write(values []T) {
+	w.writeVec([][]T{values})
+}
+
+// writeVec stores the concatenation of bufs in the buffer, making it visible
+// to readers at once. The caller must hold the lock.
+func (w *Writer[T]) writeVec(bufs [][]T) {
+	var n int64
+	for _, buf := range bufs {
+		n += int64(len(buf))
+	}
+	head := w.head.Load()
+	w.account(head, head+n)
+
+	// volume of written data may be larger than our buffer, only keep the
+	// last part (NOTE: will invalidate ALL existing readers)
+	skip := max(n-w.size, 0)
+	head += skip
+
+	// let a lock-free reader finish if we are about to overwrite its data
+	end := head + n - skip
+	w.pending.Store(end)
+	for {
+		c := w.claim.Load()
+		if c == 0 || c-1 >= end-w.size {
+			break
+		}
+		runtime.Gosched()
 	}
 
 	// copy
-	remain := w.size - w.wPos
-	copy(w.data[w.wPos:], values)
-	if int64(len(values)) > remain {
-		copy(w.data, values[remain:])
-		w.cycle += 1
-	} else if int64(len(values)) == remain {
-		w.cycle += 1
+	pos := head
+	for _, buf := range bufs {
+		if skip >= int64(len(buf)) {
+			skip -= int64(len(buf))
+			continue
+		}
+		buf = buf[skip:]
+		skip = 0
+		w.data.copyIn(pos, buf)
+		if w.expires != nil {
+			w.setExpiry(pos, buf)
+		}
+		pos += int64(len(buf))
+	}
+	if w.times != nil {
+		w.stamp(head, end)
+	}
+	if w.repeats != nil {
+		for pos := head; pos < end; pos++ {
+			w.repeats.set(pos, 1)
+		}
 	}
 
 	// update cursor position
-	w.wPos = ((w.wPos + int64(len(values))) % w.size)
+	w.head.Store(end)
+	for _, fn := range w.observers {
+		(*fn)(head, end)
+	}
+	w.checkWatermarks()
+
+	if h := w.hooks.Load(); h != nil {
+		if h.OnWrite != nil {
+			h.OnWrite(head, end)
+		}
+		if h.OnWrap != nil && (end-1)/w.size != max(head-1, 0)/w.size {
+			h.OnWrap((end - 1) / w.size)
+		}
+	}
+
+	w.verify()
+	w.notify()
+}
 
-	// wake readers
-	w.cond.Broadcast()
-	return int(n), nil
+// notify signals watchers without blocking. The caller must hold the lock.
+func (w *Writer[T]) notify() {
+	for _, ch := range w.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// watch registers ch to be notified after each write and when the writer is
+// closed, without blocking. ch should be buffered.
+func (w *Writer[T]) watch(ch chan<- struct{}) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.watchers = append(w.watchers, ch)
 }
 
+// unwatch unregisters a channel registered with watch.
+func (w *Writer[T]) unwatch(ch chan<- struct{}) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.watchers = slices.DeleteFunc(w.watchers, func(c chan<- struct{}) bool { return c == ch })
+}
+
+// Size returns the capacity of the buffer.
 func (w *Writer[T]) Size() int64 {
 	return w.size
 }
 
+// TotalWritten returns the total number of elements ever written to the
+// buffer. It is safe to call from any goroutine and does not take any lock.
 func (w *Writer[T]) TotalWritten() int64 {
-	return w.cycle*w.size + w.wPos
+	return w.head.Load()
+}
+
+// Len returns the number of elements currently retained in the buffer, which
+// is at most Size(). Like TotalWritten, it does not take any lock.
+func (w *Writer[T]) Len() int64 {
+	head := w.head.Load()
+	return head - w.oldest(head)
+}
+
+// Snapshot returns a copy of all the elements currently retained in the
+// buffer, from oldest to newest.
+func (w *Writer[T]) Snapshot() []T {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.snapshot()
+}
+
+// snapshot returns a copy of the retained elements. The caller must hold the
+// lock.
+func (w *Writer[T]) snapshot() []T {
+	head := w.head.Load()
+	oldest := w.live(head)
+
+	res := make([]T, head-oldest)
+	w.data.copyOut(res, oldest)
+	if w.expires != nil {
+		res = res[:w.filterExpired(res, oldest)]
+	}
+	return res
+}
+
+// oldest returns the position of the oldest element still retained when the
+// buffer's head is at the given position.
+func (w *Writer[T]) oldest(head int64) int64 {
+	return max(head-w.size, w.tail.Load(), 0)
+}
+
+// Truncate discards all but the n most recent elements of the buffer. Readers
+// positioned before the discarded data will silently skip it. If the writer
+// was created with NewChunked, pages which do not hold any retained data
+// anymore are released.
+func (w *Writer[T]) Truncate(n int64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.truncate(n)
+}
+
+// truncate implements Truncate. The caller must hold the lock.
+func (w *Writer[T]) truncate(n int64) {
+	head := w.head.Load()
+	tail := max(head-w.floor(max(n, 0)), w.oldest(head))
+	w.tail.Store(tail)
+	if w.framer != nil {
+		w.framer.align(tail)
+	}
+
+	// let a lock-free reader finish copying discarded data
+	for {
+		c := w.claim.Load()
+		if c == 0 || c-1 >= tail {
+			break
+		}
+		runtime.Gosched()
+	}
+
+	w.data.release(tail, head)
+	if w.times != nil {
+		w.times.release(tail, head)
+	}
+	if w.expires != nil {
+		w.expires.release(tail, head)
+	}
+	w.verify()
+}
+
+// isClosed returns true if Close has been called on the writer.
+func (w *Writer[T]) isClosed() bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.closed
 }
 
 // Close will cause all readers to return EOF once they have read the whole
@@ -172,22 +652,48 @@ func (w *Writer[T]) TotalWritten() int64 {
 // ending the program.
 //
 // Note that if any reader failed to call close prior to end and being freed
-// this may cause a deadlock. Use CloseNow() to avoid this.
+// this may cause a deadlock. Use CloseNow() to avoid this, and LeakCheck to
+// find which readers were not closed.
 func (w *Writer[T]) Close() error {
-	w.mutex.Lock()
-	if w.closed {
-		w.mutex.Unlock()
+	if !w.closeWithError(nil) {
 		// calling close multiple times isn't an error
 		return nil
 	}
-	w.closed = true
-
-	// wake all readers (they will really start moving after the unlock)
-	w.cond.Broadcast()
-
-	w.mutex.Unlock()
 
 	// wait for everyone to complete
 	w.wg.Wait()
 	return nil
 }
+
+// Closed returns true if the writer was closed.
+func (w *Writer[T]) Closed() bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.closed
+}
+
+// closeWithError marks the writer as closed, causing readers to return err
+// (or io.EOF if nil) once they have read the whole buffer. It returns false
+// if the writer was already closed.
+func (w *Writer[T]) closeWithError(err error) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return false
+	}
+	w.closed = true
+	w.closeErr = err
+
+	if l := w.logger.Load(); l != nil {
+		l.Info("ringslice: writer closed", "readers", w.readers.Load(), "total_written", w.head.Load(), "error", err)
+	}
+
+	// wake all readers and writers (they will really start moving after the
+	// unlock)
+	w.broadcast()
+	w.space.Broadcast()
+	w.notify()
+	return true
+}